@@ -36,23 +36,79 @@
 // Package aurora implements ANSI-colors
 package aurora
 
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+)
+
+// An Aurora is a colorizer. Use New to create it. It's safe for concurrent
+// use, including reconfiguration using the SetConfig method.
 type Aurora struct {
+	state atomic.Pointer[auroraState]
+}
+
+// immutable state of an Aurora, replaced atomically
+type auroraState struct {
 	conf Config
 	cc   colorConfig
 }
 
+func newAuroraState(conf Config) *auroraState {
+	return &auroraState{
+		conf: conf,
+		cc:   conf.colorConfig(), // keep the short hand
+	}
+}
+
+// state of zero Aurora
+var zeroAuroraState auroraState
+
 // New returns new colorizer by given Options.
 func New(opts ...Option) (a *Aurora) {
+	var conf = NewConfig() // set defaults
+	conf.Apply(opts...)    // apply options
 	a = new(Aurora)
-	a.conf = NewConfig()        // set defaults
-	a.conf.Apply(opts...)       // apply options
-	a.cc = a.conf.colorConfig() // keep the short hand
+	a.state.Store(newAuroraState(conf))
 	return
 }
 
+func (a *Aurora) load() *auroraState {
+	if st := a.state.Load(); st != nil {
+		return st
+	}
+	return &zeroAuroraState
+}
+
 // Config of the colorizer. It returns copy of the configurations.
 func (a *Aurora) Config() Config {
-	return a.conf
+	return a.load().conf
+}
+
+// With returns copy of the colorizer with given options applied over its
+// configurations. For example, a colorizer for a log file
+//
+//	var logColorizer = au.With(aurora.WithHyperlinks(false))
+//
+// The colorizer itself is not changed.
+func (a *Aurora) With(opts ...Option) (child *Aurora) {
+	var st = *a.load() // copy
+	st.conf.Apply(opts...)
+	st.cc = st.conf.colorConfig()
+	child = new(Aurora)
+	child.state.Store(&st)
+	return
+}
+
+// SetConfig replaces configurations of the colorizer. It's safe to call
+// the SetConfig concurrently with other methods. Every Value keeps
+// configurations of the colorizer at the moment the Value created, thus
+// Values created before the call are rendered using previous configurations,
+// and Values created after the call are rendered using new ones. Methods
+// like Sprintf use configurations taken once per call.
+func (a *Aurora) SetConfig(conf Config) {
+	a.state.Store(newAuroraState(conf))
 }
 
 // Reset wraps given argument returning Value without formats, colors and links.
@@ -61,7 +117,7 @@ func (a *Aurora) Reset(arg interface{}) Value {
 		return val.Reset()
 	}
 	return Value{
-		cc:    a.cc,
+		cc:    a.load().cc,
 		value: arg,
 	}
 }
@@ -73,7 +129,7 @@ func (a *Aurora) Clear(arg interface{}) Value {
 		return val.Clear()
 	}
 	return Value{
-		cc:    a.cc,
+		cc:    a.load().cc,
 		value: arg,
 	}
 }
@@ -86,7 +142,7 @@ func (a *Aurora) Bold(arg interface{}) Value {
 		return val.Bold()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Bold()),
+		cc:    a.load().cc | colorConfig(Color(0).Bold()),
 		value: arg,
 	}
 }
@@ -97,7 +153,7 @@ func (a *Aurora) Faint(arg interface{}) Value {
 		return val.Faint()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Faint()),
+		cc:    a.load().cc | colorConfig(Color(0).Faint()),
 		value: arg,
 	}
 }
@@ -108,7 +164,7 @@ func (a *Aurora) DoublyUnderline(arg interface{}) Value {
 		return val.DoublyUnderline()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).DoublyUnderline()),
+		cc:    a.load().cc | colorConfig(Color(0).DoublyUnderline()),
 		value: arg,
 	}
 }
@@ -119,7 +175,7 @@ func (a *Aurora) Fraktur(arg interface{}) Value {
 		return val.Fraktur()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Fraktur()),
+		cc:    a.load().cc | colorConfig(Color(0).Fraktur()),
 		value: arg,
 	}
 }
@@ -130,7 +186,7 @@ func (a *Aurora) Italic(arg interface{}) Value {
 		return val.Italic()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Italic()),
+		cc:    a.load().cc | colorConfig(Color(0).Italic()),
 		value: arg,
 	}
 }
@@ -141,7 +197,7 @@ func (a *Aurora) Underline(arg interface{}) Value {
 		return val.Underline()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Underline()),
+		cc:    a.load().cc | colorConfig(Color(0).Underline()),
 		value: arg,
 	}
 }
@@ -152,7 +208,7 @@ func (a *Aurora) SlowBlink(arg interface{}) Value {
 		return val.SlowBlink()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).SlowBlink()),
+		cc:    a.load().cc | colorConfig(Color(0).SlowBlink()),
 		value: arg,
 	}
 }
@@ -163,7 +219,7 @@ func (a *Aurora) RapidBlink(arg interface{}) Value {
 		return val.RapidBlink()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).RapidBlink()),
+		cc:    a.load().cc | colorConfig(Color(0).RapidBlink()),
 		value: arg,
 	}
 }
@@ -174,7 +230,7 @@ func (a *Aurora) Blink(arg interface{}) Value {
 		return val.Blink()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Blink()),
+		cc:    a.load().cc | colorConfig(Color(0).Blink()),
 		value: arg,
 	}
 }
@@ -185,7 +241,7 @@ func (a *Aurora) Reverse(arg interface{}) Value {
 		return val.Reverse()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Reverse()),
+		cc:    a.load().cc | colorConfig(Color(0).Reverse()),
 		value: arg,
 	}
 }
@@ -196,7 +252,7 @@ func (a *Aurora) Inverse(arg interface{}) Value {
 		return val.Inverse()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Inverse()),
+		cc:    a.load().cc | colorConfig(Color(0).Inverse()),
 		value: arg,
 	}
 }
@@ -207,7 +263,7 @@ func (a *Aurora) Conceal(arg interface{}) Value {
 		return val.Conceal()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Conceal()),
+		cc:    a.load().cc | colorConfig(Color(0).Conceal()),
 		value: arg,
 	}
 }
@@ -218,7 +274,7 @@ func (a *Aurora) Hidden(arg interface{}) Value {
 		return val.Hidden()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Hidden()),
+		cc:    a.load().cc | colorConfig(Color(0).Hidden()),
 		value: arg,
 	}
 }
@@ -229,7 +285,7 @@ func (a *Aurora) CrossedOut(arg interface{}) Value {
 		return val.CrossedOut()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).CrossedOut()),
+		cc:    a.load().cc | colorConfig(Color(0).CrossedOut()),
 		value: arg,
 	}
 }
@@ -240,7 +296,7 @@ func (a *Aurora) StrikeThrough(arg interface{}) Value {
 		return val.StrikeThrough()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).StrikeThrough()),
+		cc:    a.load().cc | colorConfig(Color(0).StrikeThrough()),
 		value: arg,
 	}
 }
@@ -251,7 +307,7 @@ func (a *Aurora) Framed(arg interface{}) Value {
 		return val.Framed()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Framed()),
+		cc:    a.load().cc | colorConfig(Color(0).Framed()),
 		value: arg,
 	}
 }
@@ -262,7 +318,7 @@ func (a *Aurora) Encircled(arg interface{}) Value {
 		return val.Encircled()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Encircled()),
+		cc:    a.load().cc | colorConfig(Color(0).Encircled()),
 		value: arg,
 	}
 }
@@ -273,7 +329,142 @@ func (a *Aurora) Overlined(arg interface{}) Value {
 		return val.Overlined()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Overlined()),
+		cc:    a.load().cc | colorConfig(Color(0).Overlined()),
+		value: arg,
+	}
+}
+
+// Curly underline, not widely supported (4:3).
+func (a *Aurora) CurlyUnderline(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.CurlyUnderline()
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).CurlyUnderline()),
+		value: arg,
+	}
+}
+
+// Dotted underline, not widely supported (4:4).
+func (a *Aurora) DottedUnderline(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.DottedUnderline()
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).DottedUnderline()),
+		value: arg,
+	}
+}
+
+// Dashed underline, not widely supported (4:5).
+func (a *Aurora) DashedUnderline(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.DashedUnderline()
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).DashedUnderline()),
+		value: arg,
+	}
+}
+
+// Proportional spacing, rarely supported (26).
+func (a *Aurora) ProportionalSpacing(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.ProportionalSpacing()
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).ProportionalSpacing()),
+		value: arg,
+	}
+}
+
+// IdeogramUnderline or right side line, rarely supported (60).
+func (a *Aurora) IdeogramUnderline(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.IdeogramUnderline()
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).IdeogramUnderline()),
+		value: arg,
+	}
+}
+
+// IdeogramDoubleUnderline or double line on the right side, rarely
+// supported (61).
+func (a *Aurora) IdeogramDoubleUnderline(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.IdeogramDoubleUnderline()
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).IdeogramDoubleUnderline()),
+		value: arg,
+	}
+}
+
+// IdeogramOverline or left side line, rarely supported (62).
+func (a *Aurora) IdeogramOverline(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.IdeogramOverline()
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).IdeogramOverline()),
+		value: arg,
+	}
+}
+
+// IdeogramDoubleOverline or double line on the left side, rarely
+// supported (63).
+func (a *Aurora) IdeogramDoubleOverline(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.IdeogramDoubleOverline()
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).IdeogramDoubleOverline()),
+		value: arg,
+	}
+}
+
+// IdeogramStress marking, rarely supported (64).
+func (a *Aurora) IdeogramStress(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.IdeogramStress()
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).IdeogramStress()),
+		value: arg,
+	}
+}
+
+// Superscript, reset the Subscript, rarely supported (73).
+func (a *Aurora) Superscript(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.Superscript()
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).Superscript()),
+		value: arg,
+	}
+}
+
+// Subscript, reset the Superscript, rarely supported (74).
+func (a *Aurora) Subscript(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.Subscript()
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).Subscript()),
+		value: arg,
+	}
+}
+
+// Font sets alternative font from 1 to 9 (11-19), rarely supported. The
+// zero is the primary font (10).
+func (a *Aurora) Font(n FontIndex, arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.Font(n)
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).Font(n)),
 		value: arg,
 	}
 }
@@ -286,7 +477,7 @@ func (a *Aurora) Black(arg interface{}) Value {
 		return val.Black()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Black()),
+		cc:    a.load().cc | colorConfig(Color(0).Black()),
 		value: arg,
 	}
 }
@@ -297,7 +488,7 @@ func (a *Aurora) Red(arg interface{}) Value {
 		return val.Red()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Red()),
+		cc:    a.load().cc | colorConfig(Color(0).Red()),
 		value: arg,
 	}
 }
@@ -308,7 +499,7 @@ func (a *Aurora) Green(arg interface{}) Value {
 		return val.Green()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Green()),
+		cc:    a.load().cc | colorConfig(Color(0).Green()),
 		value: arg,
 	}
 }
@@ -319,7 +510,7 @@ func (a *Aurora) Yellow(arg interface{}) Value {
 		return val.Yellow()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Yellow()),
+		cc:    a.load().cc | colorConfig(Color(0).Yellow()),
 		value: arg,
 	}
 }
@@ -330,7 +521,7 @@ func (a *Aurora) Blue(arg interface{}) Value {
 		return val.Blue()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Blue()),
+		cc:    a.load().cc | colorConfig(Color(0).Blue()),
 		value: arg,
 	}
 }
@@ -341,7 +532,7 @@ func (a *Aurora) Magenta(arg interface{}) Value {
 		return val.Magenta()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Magenta()),
+		cc:    a.load().cc | colorConfig(Color(0).Magenta()),
 		value: arg,
 	}
 }
@@ -352,7 +543,7 @@ func (a *Aurora) Cyan(arg interface{}) Value {
 		return val.Cyan()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Cyan()),
+		cc:    a.load().cc | colorConfig(Color(0).Cyan()),
 		value: arg,
 	}
 }
@@ -363,7 +554,7 @@ func (a *Aurora) White(arg interface{}) Value {
 		return val.White()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).White()),
+		cc:    a.load().cc | colorConfig(Color(0).White()),
 		value: arg,
 	}
 }
@@ -376,7 +567,7 @@ func (a *Aurora) BrightBlack(arg interface{}) Value {
 		return val.BrightBlack()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BrightBlack()),
+		cc:    a.load().cc | colorConfig(Color(0).BrightBlack()),
 		value: arg,
 	}
 }
@@ -387,7 +578,7 @@ func (a *Aurora) BrightRed(arg interface{}) Value {
 		return val.BrightRed()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BrightRed()),
+		cc:    a.load().cc | colorConfig(Color(0).BrightRed()),
 		value: arg,
 	}
 }
@@ -398,7 +589,7 @@ func (a *Aurora) BrightGreen(arg interface{}) Value {
 		return val.BrightGreen()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BrightGreen()),
+		cc:    a.load().cc | colorConfig(Color(0).BrightGreen()),
 		value: arg,
 	}
 }
@@ -409,7 +600,7 @@ func (a *Aurora) BrightYellow(arg interface{}) Value {
 		return val.BrightYellow()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BrightYellow()),
+		cc:    a.load().cc | colorConfig(Color(0).BrightYellow()),
 		value: arg,
 	}
 }
@@ -420,7 +611,7 @@ func (a *Aurora) BrightBlue(arg interface{}) Value {
 		return val.BrightBlue()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BrightBlue()),
+		cc:    a.load().cc | colorConfig(Color(0).BrightBlue()),
 		value: arg,
 	}
 }
@@ -431,7 +622,7 @@ func (a *Aurora) BrightMagenta(arg interface{}) Value {
 		return val.BrightMagenta()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BrightMagenta()),
+		cc:    a.load().cc | colorConfig(Color(0).BrightMagenta()),
 		value: arg,
 	}
 }
@@ -442,7 +633,7 @@ func (a *Aurora) BrightCyan(arg interface{}) Value {
 		return val.BrightCyan()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BrightCyan()),
+		cc:    a.load().cc | colorConfig(Color(0).BrightCyan()),
 		value: arg,
 	}
 }
@@ -453,7 +644,7 @@ func (a *Aurora) BrightWhite(arg interface{}) Value {
 		return val.BrightWhite()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BrightWhite()),
+		cc:    a.load().cc | colorConfig(Color(0).BrightWhite()),
 		value: arg,
 	}
 }
@@ -471,8 +662,21 @@ func (a *Aurora) Index(n ColorIndex, arg interface{}) Value {
 		return val.Index(n)
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Index(n)),
+		cc:    a.load().cc | colorConfig(Color(0).Index(n)),
+		value: arg,
+	}
+}
+
+// TrueColor sets 24-bit foreground color (38;2;r;g;b), used with the
+// ProfileTrueColor. Other profiles use nearest 8-bit color, see RGB.Index.
+func (a *Aurora) TrueColor(rgb RGB, arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.TrueColor(rgb)
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).TrueColor(rgb)),
 		value: arg,
+		rgb:   &trueColors{fg: rgb, hasFg: true},
 	}
 }
 
@@ -482,7 +686,7 @@ func (a *Aurora) Gray(n GrayIndex, arg interface{}) Value {
 		return val.Gray(n)
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).Gray(n)),
+		cc:    a.load().cc | colorConfig(Color(0).Gray(n)),
 		value: arg,
 	}
 }
@@ -495,7 +699,7 @@ func (a *Aurora) BgBlack(arg interface{}) Value {
 		return val.BgBlack()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgBlack()),
+		cc:    a.load().cc | colorConfig(Color(0).BgBlack()),
 		value: arg,
 	}
 }
@@ -506,7 +710,7 @@ func (a *Aurora) BgRed(arg interface{}) Value {
 		return val.BgRed()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgRed()),
+		cc:    a.load().cc | colorConfig(Color(0).BgRed()),
 		value: arg,
 	}
 }
@@ -517,7 +721,7 @@ func (a *Aurora) BgGreen(arg interface{}) Value {
 		return val.BgGreen()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgGreen()),
+		cc:    a.load().cc | colorConfig(Color(0).BgGreen()),
 		value: arg,
 	}
 }
@@ -528,7 +732,7 @@ func (a *Aurora) BgYellow(arg interface{}) Value {
 		return val.BgYellow()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgYellow()),
+		cc:    a.load().cc | colorConfig(Color(0).BgYellow()),
 		value: arg,
 	}
 }
@@ -539,7 +743,7 @@ func (a *Aurora) BgBlue(arg interface{}) Value {
 		return val.BgBlue()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgBlue()),
+		cc:    a.load().cc | colorConfig(Color(0).BgBlue()),
 		value: arg,
 	}
 }
@@ -550,7 +754,7 @@ func (a *Aurora) BgMagenta(arg interface{}) Value {
 		return val.BgMagenta()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgMagenta()),
+		cc:    a.load().cc | colorConfig(Color(0).BgMagenta()),
 		value: arg,
 	}
 }
@@ -561,7 +765,7 @@ func (a *Aurora) BgCyan(arg interface{}) Value {
 		return val.BgCyan()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgCyan()),
+		cc:    a.load().cc | colorConfig(Color(0).BgCyan()),
 		value: arg,
 	}
 }
@@ -572,7 +776,7 @@ func (a *Aurora) BgWhite(arg interface{}) Value {
 		return val.BgWhite()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgWhite()),
+		cc:    a.load().cc | colorConfig(Color(0).BgWhite()),
 		value: arg,
 	}
 }
@@ -585,7 +789,7 @@ func (a *Aurora) BgBrightBlack(arg interface{}) Value {
 		return val.BgBrightBlack()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgBrightBlack()),
+		cc:    a.load().cc | colorConfig(Color(0).BgBrightBlack()),
 		value: arg,
 	}
 }
@@ -596,7 +800,7 @@ func (a *Aurora) BgBrightRed(arg interface{}) Value {
 		return val.BgBrightRed()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgBrightRed()),
+		cc:    a.load().cc | colorConfig(Color(0).BgBrightRed()),
 		value: arg,
 	}
 }
@@ -607,7 +811,7 @@ func (a *Aurora) BgBrightGreen(arg interface{}) Value {
 		return val.BgBrightGreen()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgBrightGreen()),
+		cc:    a.load().cc | colorConfig(Color(0).BgBrightGreen()),
 		value: arg,
 	}
 }
@@ -618,7 +822,7 @@ func (a *Aurora) BgBrightYellow(arg interface{}) Value {
 		return val.BgBrightYellow()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgBrightYellow()),
+		cc:    a.load().cc | colorConfig(Color(0).BgBrightYellow()),
 		value: arg,
 	}
 }
@@ -629,7 +833,7 @@ func (a *Aurora) BgBrightBlue(arg interface{}) Value {
 		return val.BgBrightBlue()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgBrightBlue()),
+		cc:    a.load().cc | colorConfig(Color(0).BgBrightBlue()),
 		value: arg,
 	}
 }
@@ -640,7 +844,7 @@ func (a *Aurora) BgBrightMagenta(arg interface{}) Value {
 		return val.BgBrightMagenta()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgBrightMagenta()),
+		cc:    a.load().cc | colorConfig(Color(0).BgBrightMagenta()),
 		value: arg,
 	}
 }
@@ -651,7 +855,7 @@ func (a *Aurora) BgBrightCyan(arg interface{}) Value {
 		return val.BgBrightCyan()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgBrightCyan()),
+		cc:    a.load().cc | colorConfig(Color(0).BgBrightCyan()),
 		value: arg,
 	}
 }
@@ -662,7 +866,7 @@ func (a *Aurora) BgBrightWhite(arg interface{}) Value {
 		return val.BgBrightWhite()
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgBrightWhite()),
+		cc:    a.load().cc | colorConfig(Color(0).BgBrightWhite()),
 		value: arg,
 	}
 }
@@ -680,7 +884,7 @@ func (a *Aurora) BgIndex(n ColorIndex, arg interface{}) Value {
 		return val.BgIndex(n)
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgIndex(n)),
+		cc:    a.load().cc | colorConfig(Color(0).BgIndex(n)),
 		value: arg,
 	}
 }
@@ -691,7 +895,266 @@ func (a *Aurora) BgGray(n GrayIndex, arg interface{}) Value {
 		return val.BgGray(n)
 	}
 	return Value{
-		cc:    a.cc | colorConfig(Color(0).BgGray(n)),
+		cc:    a.load().cc | colorConfig(Color(0).BgGray(n)),
+		value: arg,
+	}
+}
+
+// BgTrueColor sets 24-bit background color (48;2;r;g;b). See TrueColor.
+func (a *Aurora) BgTrueColor(rgb RGB, arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.BgTrueColor(rgb)
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).BgTrueColor(rgb)),
+		value: arg,
+		rgb:   &trueColors{bg: rgb, hasBg: true},
+	}
+}
+
+// Underline color.
+// UnderlineIndex sets underline color, 8-bit pre-defined color from 0 to
+// 255 (58;5;n), not widely supported. See Index for details.
+func (a *Aurora) UnderlineIndex(n ColorIndex, arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.UnderlineIndex(n)
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).UnderlineIndex(n)),
+		value: arg,
+	}
+}
+
+// UnderlineGray sets gray underline color from 0 to 23.
+func (a *Aurora) UnderlineGray(n GrayIndex, arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.UnderlineGray(n)
+	}
+	return Value{
+		cc:    a.load().cc | colorConfig(Color(0).UnderlineGray(n)),
+		value: arg,
+	}
+}
+
+// Removal.
+//
+// NoBold removes Bold format.
+func (a *Aurora) NoBold(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoBold()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoFaint removes Faint format.
+func (a *Aurora) NoFaint(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoFaint()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoItalic removes Italic format.
+func (a *Aurora) NoItalic(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoItalic()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoUnderline removes Underline and DoublyUnderline formats.
+func (a *Aurora) NoUnderline(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoUnderline()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoBlink removes SlowBlink and RapidBlink formats.
+func (a *Aurora) NoBlink(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoBlink()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoReverse removes Reverse format.
+func (a *Aurora) NoReverse(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoReverse()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoConceal removes Conceal format.
+func (a *Aurora) NoConceal(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoConceal()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoCrossedOut removes CrossedOut format.
+func (a *Aurora) NoCrossedOut(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoCrossedOut()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoFraktur removes Fraktur format.
+func (a *Aurora) NoFraktur(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoFraktur()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoFramed removes Framed format.
+func (a *Aurora) NoFramed(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoFramed()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoEncircled removes Encircled format.
+func (a *Aurora) NoEncircled(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoEncircled()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoOverlined removes Overlined format.
+func (a *Aurora) NoOverlined(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoOverlined()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoForeground removes foreground color.
+func (a *Aurora) NoForeground(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoForeground()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoBackground removes background color.
+func (a *Aurora) NoBackground(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoBackground()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoUnderlineColor removes underline color.
+func (a *Aurora) NoUnderlineColor(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoUnderlineColor()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoProportionalSpacing removes ProportionalSpacing format.
+func (a *Aurora) NoProportionalSpacing(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoProportionalSpacing()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoIdeograms removes all ideogram formats.
+func (a *Aurora) NoIdeograms(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoIdeograms()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoScript removes Superscript and Subscript formats.
+func (a *Aurora) NoScript(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoScript()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// NoFont removes alternative font, using the primary one.
+func (a *Aurora) NoFont(arg interface{}) Value {
+	if val, ok := arg.(Value); ok {
+		return val.NoFont()
+	}
+	return Value{
+		cc:    a.load().cc,
+		value: arg,
+	}
+}
+
+// Without removes formats and colors of given Color from the argument.
+// See Color.Without.
+func (a *Aurora) Without(arg interface{}, color Color) Value {
+	if val, ok := arg.(Value); ok {
+		return val.Without(color)
+	}
+	return Value{
+		cc:    a.load().cc,
 		value: arg,
 	}
 }
@@ -705,7 +1168,7 @@ func (a *Aurora) Colorize(arg interface{}, color Color) Value {
 		return val.Colorize(color)
 	}
 	return Value{
-		cc:    a.cc | colorConfig(color),
+		cc:    a.load().cc | colorConfig(color)&maskColor,
 		value: arg,
 	}
 }
@@ -738,7 +1201,7 @@ func (a *Aurora) Hyperlink(arg interface{}, target string,
 		return val.Hyperlink(target, params...)
 	}
 	return Value{
-		cc:    a.cc,
+		cc:    a.load().cc,
 		value: arg,
 	}.Hyperlink(target, params...)
 }
@@ -759,22 +1222,53 @@ func (a *Aurora) HyperlinkParams(arg interface{}) (params []HyperlinkParam) {
 	return // no target
 }
 
-func (a *Aurora) transform(arg interface{}) (val Value, ok bool) {
+// Semantic roles.
+//
+// Role applies Style of given role of the Theme to the argument. Unknown
+// roles leave the argument as is. See Role* constants for available roles.
+func (a *Aurora) Role(role string, arg interface{}) Value {
+	var st = a.load()
+	if style, ok := st.conf.Theme.Style(role); ok {
+		return style.apply(st.cc, arg)
+	}
+	if val, ok := transform(st.cc, arg); ok {
+		return val
+	}
+	return Value{
+		cc:    st.cc,
+		value: arg,
+	}
+}
+
+// transform given Value applying given configurations
+func transform(cc colorConfig, arg interface{}) (val Value, ok bool) {
 	var ai Value
 	ai, ok = arg.(Value)
 	if !ok {
 		return // Value{}, false
 	}
-	// if ai.cc.resetColor() == a.cc.resetColor() {
+	// if ai.cc.resetColor() == cc.resetColor() {
 	// 	return // don't replace, same configurations
 	// }
-	val = Value{cc: a.cc | colorConfig(ai.cc.color()), value: ai.value}
-	if a.cc.hyperlinksEnbaled() {
+	val = Value{
+		cc:    cc | colorConfig(ai.cc.color()),
+		value: ai.value,
+		rgb:   ai.rgb,
+	}
+	if cc.hyperlinksEnbaled() {
 		val.hyperlink = ai.hyperlink
 	}
 	return val, true // transformed value, true
 }
 
+func transformArgs(cc colorConfig, args []interface{}) {
+	for i := range args {
+		if ax, ok := transform(cc, args[i]); ok {
+			args[i] = ax
+		}
+	}
+}
+
 // Sprintf allows to use Value as format. For example
 //
 //	var v = Sprintf(Red("total: +3.5f points"), Blue(3.14))
@@ -790,13 +1284,73 @@ func (a *Aurora) transform(arg interface{}) (val Value, ok bool) {
 // It applies own configurations to all given Values.
 func (a *Aurora) Sprintf(format interface{}, args ...interface{}) string {
 	// // clear colors & links as configured by the a
-	if f, ok := a.transform(format); ok {
+	var cc = a.load().cc
+	if f, ok := transform(cc, format); ok {
 		format = f
 	}
-	for i := range args {
-		if ax, ok := a.transform(args[i]); ok {
-			args[i] = ax
-		}
-	}
+	transformArgs(cc, args)
 	return sprintf(format, args...)
 }
+
+// Sprint is like fmt.Sprint, but it applies own configurations to all
+// given Values.
+func (a *Aurora) Sprint(args ...interface{}) string {
+	transformArgs(a.load().cc, args)
+	return fmt.Sprint(args...)
+}
+
+// Sprintln is like fmt.Sprintln, but it applies own configurations to all
+// given Values.
+func (a *Aurora) Sprintln(args ...interface{}) string {
+	transformArgs(a.load().cc, args)
+	return fmt.Sprintln(args...)
+}
+
+// Fprintf is like the Sprintf, but it writes result to given io.Writer
+// directly. It returns number of bytes written and any write error
+// encountered.
+func (a *Aurora) Fprintf(w io.Writer, format interface{},
+	args ...interface{}) (n int, err error) {
+
+	var cc = a.load().cc
+	if f, ok := transform(cc, format); ok {
+		format = f
+	}
+	transformArgs(cc, args)
+	return fprintf(w, format, args...)
+}
+
+// Fprint is like fmt.Fprint, but it applies own configurations to all
+// given Values.
+func (a *Aurora) Fprint(w io.Writer, args ...interface{}) (n int, err error) {
+	transformArgs(a.load().cc, args)
+	return fmt.Fprint(w, args...)
+}
+
+// Fprintln is like fmt.Fprintln, but it applies own configurations to all
+// given Values.
+func (a *Aurora) Fprintln(w io.Writer, args ...interface{}) (n int,
+	err error) {
+
+	transformArgs(a.load().cc, args)
+	return fmt.Fprintln(w, args...)
+}
+
+// Printf is like the Fprintf, but it writes to standard output.
+func (a *Aurora) Printf(format interface{}, args ...interface{}) (n int,
+	err error) {
+
+	return a.Fprintf(os.Stdout, format, args...)
+}
+
+// Print is like fmt.Print, but it applies own configurations to all
+// given Values.
+func (a *Aurora) Print(args ...interface{}) (n int, err error) {
+	return a.Fprint(os.Stdout, args...)
+}
+
+// Println is like fmt.Println, but it applies own configurations to all
+// given Values.
+func (a *Aurora) Println(args ...interface{}) (n int, err error) {
+	return a.Fprintln(os.Stdout, args...)
+}
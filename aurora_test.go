@@ -36,11 +36,20 @@
 package aurora
 
 import (
+	"os"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+func TestMain(m *testing.M) {
+	// the tests expect colored output from package root methods,
+	// regardless is the standard output a terminal or not
+	SetDefault(New())
+	os.Exit(m.Run())
+}
+
 func isColor(v Value, clr Color) bool {
 	return v.Color() == clr
 }
@@ -50,15 +59,15 @@ func Test_New(t *testing.T) {
 		dconf = NewConfig() // default configurations
 		a     = New()
 	)
-	assert.Equal(t, dconf, a.conf, "non-default configurations")
+	assert.Equal(t, dconf, a.Config(), "non-default configurations")
 	// options
 	a = New(WithColors(true), WithHyperlinks(true))
-	assert.True(t, a.conf.Colors)
-	assert.True(t, a.conf.Hyperlinks)
+	assert.True(t, a.Config().Colors)
+	assert.True(t, a.Config().Hyperlinks)
 	// colors
 	a = New(WithColors(false), WithHyperlinks(false))
-	assert.False(t, a.conf.Colors)
-	assert.False(t, a.conf.Hyperlinks)
+	assert.False(t, a.Config().Colors)
+	assert.False(t, a.Config().Hyperlinks)
 
 }
 
@@ -66,6 +75,80 @@ func TestAurora_Config(t *testing.T) {
 	assert.Equal(t, NewConfig(), New().Config())
 }
 
+func TestAurora_With(t *testing.T) {
+	var (
+		parent = New(WithProfile(ProfileANSI16))
+		theme  = DefaultTheme()
+	)
+	theme.Error = NewStyle().Magenta()
+	var child = parent.With(WithHyperlinks(false), WithTheme(theme))
+	assert.True(t, child.Config().Colors)
+	assert.False(t, child.Config().Hyperlinks)
+	assert.Equal(t, ProfileANSI16, child.Config().Profile)
+	assert.Equal(t, theme, child.Config().Theme)
+	assert.Equal(t, "\033[35mx\033[0m", child.Role(RoleError, "x").String())
+	assert.Equal(t, "http://example.com",
+		child.Hyperlink("x", "http://example.com").String())
+	// the parent is not changed
+	assert.Equal(t, New(WithProfile(ProfileANSI16)).Config(), parent.Config())
+	// changing the parent doesn't affect the child
+	parent.SetConfig(Config{})
+	assert.True(t, child.Config().Colors)
+	// no options
+	assert.Equal(t, parent.Config(), parent.With().Config())
+	assert.False(t, parent == parent.With())
+	// zero
+	var z Aurora
+	assert.Equal(t, Config{Colors: true}, z.With(WithColors(true)).Config())
+}
+
+func TestAurora_SetConfig(t *testing.T) {
+	var (
+		a      = New()
+		before = a.Red("x")
+	)
+	a.SetConfig(Config{Colors: false, Hyperlinks: false})
+	assert.Equal(t, Config{}, a.Config())
+	var after = a.Red("x")
+	assert.Equal(t, "\033[31mx\033[0m", before.String())
+	assert.Equal(t, "x", after.String())
+	assert.Equal(t, "x", a.Sprintf(before))
+	// zero
+	var z Aurora
+	assert.Equal(t, Config{}, z.Config())
+	assert.Equal(t, "x", z.Red("x").String())
+	z.SetConfig(NewConfig())
+	assert.Equal(t, "\033[31mx\033[0m", z.Red("x").String())
+}
+
+func TestAurora_SetConfig_concurrent(t *testing.T) {
+	var (
+		a  = New()
+		wg sync.WaitGroup
+	)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				a.SetConfig(Config{Colors: (i+j)%2 == 0})
+			}
+		}(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				var s = a.Sprintf(a.Red("%s"), a.Blue("x"))
+				if s != "x" {
+					assert.Equal(t,
+						"\033[31m\033[34mx\033[31m\033[0m", s)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func TestAurora_no_colors(t *testing.T) {
 
 	var a = New(WithColors(false), WithHyperlinks(false))
@@ -215,14 +298,78 @@ func TestAurora_colored(t *testing.T) {
 	test("BgBrightWhite", a.BgBrightWhite("x"), BrightBg|WhiteBg)
 	test("BgIndex", a.BgIndex(187, "x"), (Color(187)<<shiftBg)|flagBg)
 	test("BgGray", a.BgGray(15, "x"), (Color(15+232)<<shiftBg)|flagBg)
+	test("TrueColor", a.TrueColor(0xff8800, "x"), (Color(208)<<shiftFg)|flagFg)
+	test("BgTrueColor", a.BgTrueColor(0xff8800, "x"),
+		(Color(208)<<shiftBg)|flagBg)
+
+	test("CurlyUnderline", a.CurlyUnderline("x"), CurlyUnderlineFm)
+	test("DottedUnderline", a.DottedUnderline("x"), DottedUnderlineFm)
+	test("DashedUnderline", a.DashedUnderline("x"), DashedUnderlineFm)
+	test("UnderlineIndex", a.UnderlineIndex(187, "x"),
+		(Color(187)<<shiftUl)|flagUl)
+	test("UnderlineGray", a.UnderlineGray(15, "x"),
+		(Color(15+232)<<shiftUl)|flagUl)
+	test("NoUnderlineColor", a.NoUnderlineColor(a.UnderlineGray(15, "x")), 0)
+
+	test("ProportionalSpacing", a.ProportionalSpacing("x"),
+		ProportionalSpacingFm)
+	test("IdeogramUnderline", a.IdeogramUnderline("x"), IdeogramUnderlineFm)
+	test("IdeogramDoubleUnderline", a.IdeogramDoubleUnderline("x"),
+		IdeogramDoubleUnderlineFm)
+	test("IdeogramOverline", a.IdeogramOverline("x"), IdeogramOverlineFm)
+	test("IdeogramDoubleOverline", a.IdeogramDoubleOverline("x"),
+		IdeogramDoubleOverlineFm)
+	test("IdeogramStress", a.IdeogramStress("x"), IdeogramStressFm)
+	test("Superscript", a.Superscript(a.Subscript("x")), SuperscriptFm)
+	test("Subscript", a.Subscript(a.Superscript("x")), SubscriptFm)
+	test("Font", a.Font(5, "x"), Color(5)<<shiftFont)
+	test("NoProportionalSpacing",
+		a.NoProportionalSpacing(a.ProportionalSpacing("x")), 0)
+	test("NoIdeograms", a.NoIdeograms(a.IdeogramStress("x")), 0)
+	test("NoScript", a.NoScript(a.Subscript("x")), 0)
+	test("NoFont", a.NoFont(a.Font(5, "x")), 0)
 
 	test("Colorize", a.Colorize("x", RedFg|BlueBg|BrightBg|BoldFm),
 		RedFg|BlueBg|BrightBg|BoldFm)
 }
 
+func TestAurora_removal(t *testing.T) {
+	var a = New()
+	var v = a.Colorize("x", BoldFm|FaintFm|ItalicFm|UnderlineFm|SlowBlinkFm|
+		ReverseFm|ConcealFm|CrossedOutFm|FrakturFm|FramedFm|EncircledFm|
+		OverlinedFm|RedFg|BlueBg)
+	for _, tt := range []struct {
+		name string
+		val  Value
+		want Color
+	}{
+		{"NoBold", a.NoBold(v), v.Color() &^ BoldFm},
+		{"NoFaint", a.NoFaint(v), v.Color() &^ FaintFm},
+		{"NoItalic", a.NoItalic(v), v.Color() &^ ItalicFm},
+		{"NoUnderline", a.NoUnderline(v), v.Color() &^ UnderlineFm},
+		{"NoBlink", a.NoBlink(v), v.Color() &^ SlowBlinkFm},
+		{"NoReverse", a.NoReverse(v), v.Color() &^ ReverseFm},
+		{"NoConceal", a.NoConceal(v), v.Color() &^ ConcealFm},
+		{"NoCrossedOut", a.NoCrossedOut(v), v.Color() &^ CrossedOutFm},
+		{"NoFraktur", a.NoFraktur(v), v.Color() &^ FrakturFm},
+		{"NoFramed", a.NoFramed(v), v.Color() &^ FramedFm},
+		{"NoEncircled", a.NoEncircled(v), v.Color() &^ EncircledFm},
+		{"NoOverlined", a.NoOverlined(v), v.Color() &^ OverlinedFm},
+		{"NoForeground", a.NoForeground(v), v.Color() &^ RedFg},
+		{"NoBackground", a.NoBackground(v), v.Color() &^ BlueBg},
+		{"Without", a.Without(v, BoldFm|ItalicFm|BlackBg),
+			v.Color() &^ (BoldFm | ItalicFm | BlueBg)},
+		{"plain", a.NoBold("x"), 0},
+		{"plain Without", a.Without("x", BoldFm), 0},
+	} {
+		assert.Equal(t, tt.want, tt.val.Color(), tt.name)
+		assert.Equal(t, "x", tt.val.Value(), tt.name)
+	}
+}
+
 func TestAurora_Sprintf(t *testing.T) {
 	var a = New()
-	assert.Equal(t, "\033[30mx: \033[0;34m2\033[0;30mB\033[0m",
+	assert.Equal(t, "\033[30mx: \033[34m2\033[30mB\033[0m",
 		a.Sprintf(a.Black("x: %dB"), a.Blue(2)))
 	assert.Equal(t, "x: \033[34m2\033[0mB",
 		a.Sprintf("x: %dB", a.Blue(2)))
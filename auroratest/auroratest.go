@@ -0,0 +1,193 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+// Package auroratest provides helpers to test styled output. Strings are
+// compared semantically: the same visible text, the same colors and
+// formats of every rune, and the same hyperlinks. Thus, different but
+// equivalent escape sequences, like "\033[1;31m" and "\033[0;31;1m", don't
+// break tests.
+//
+//	func TestReport(t *testing.T) {
+//		auroratest.Equal(t, "\033[1;31merror\033[0m", report())
+//		auroratest.Golden(t, "testdata/report.golden", report())
+//	}
+//
+// Golden files are updated by the -auroratest.update flag.
+//
+//	go test ./... -auroratest.update
+package auroratest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+// Update golden files instead of comparing with them.
+var update = flag.Bool("auroratest.update", false, "update golden files")
+
+// TB is subset of the testing.TB used by the package.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+// a style of a rune
+type style struct {
+	color  aurora.Color
+	link   string
+	params string
+}
+
+func (s style) String() string {
+	var parts []string
+	if s.color != 0 {
+		parts = append(parts,
+			aurora.NewStyle().Colorize(s.color).String())
+	}
+	if s.link != "" {
+		var link = "link=" + s.link
+		if s.params != "" {
+			link += " " + s.params
+		}
+		parts = append(parts, link)
+	}
+	if len(parts) == 0 {
+		return "plain"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// runes and styles of them
+func runes(s string) (rs []rune, styles []style) {
+	for _, span := range aurora.Spans(s) {
+		var st = style{color: span.Color, link: span.Link}
+		for i, p := range span.Params {
+			if i > 0 {
+				st.params += ":"
+			}
+			st.params += p.String()
+		}
+		for _, r := range span.Text {
+			rs = append(rs, r)
+			styles = append(styles, st)
+		}
+	}
+	return
+}
+
+// Diff returns human readable difference of given strings, or empty
+// string if they look the same.
+func Diff(want, got string) string {
+	var (
+		wr, ws = runes(want)
+		gr, gs = runes(got)
+		b      strings.Builder
+	)
+	if string(wr) != string(gr) {
+		fmt.Fprintf(&b, "visible text differs:\n\twant: %q\n\tgot:  %q\n",
+			string(wr), string(gr))
+	} else {
+		for i := 0; i < len(ws); {
+			if ws[i] == gs[i] {
+				i++
+				continue
+			}
+			var j = i + 1
+			for j < len(ws) && ws[j] == ws[i] && gs[j] == gs[i] {
+				j++
+			}
+			fmt.Fprintf(&b, "style differs at runes [%d:%d] %q:\n"+
+				"\twant: %s\n\tgot:  %s\n", i, j, string(wr[i:j]), ws[i], gs[i])
+			i = j
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	fmt.Fprintf(&b, "inspect:\n\twant: %s\n\tgot:  %s\n",
+		aurora.Inspect(want), aurora.Inspect(got))
+	return b.String()
+}
+
+// Equal reports whether given strings look the same. It reports an error
+// with human readable difference otherwise.
+func Equal(t TB, want, got string) bool {
+	t.Helper()
+	if diff := Diff(want, got); diff != "" {
+		t.Errorf("styled output mismatch:\n%s", diff)
+		return false
+	}
+	return true
+}
+
+// Golden compares given output with content of given golden file, like
+// Equal. If the -auroratest.update flag is set, then the file is written
+// instead, creating parent directories if needed.
+func Golden(t TB, path, got string) bool {
+	t.Helper()
+	if *update {
+		var err = os.MkdirAll(filepath.Dir(path), 0o755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(got), 0o644)
+		}
+		if err != nil {
+			t.Fatalf("updating golden file: %v", err)
+		}
+		return true
+	}
+	var want, err = os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("golden file %s not found, use -auroratest.update flag"+
+			" to create it", path)
+		return false
+	}
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+		return false
+	}
+	if diff := Diff(string(want), got); diff != "" {
+		t.Errorf("styled output mismatch with %s:\n%s", path, diff)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package auroratest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+// fake TB recording errors
+type fakeT struct {
+	errors []string
+	fatal  bool
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Fatalf(format string, args ...interface{}) {
+	f.Errorf(format, args...)
+	f.fatal = true
+}
+
+func TestDiff(t *testing.T) {
+	for _, eq := range [][2]string{
+		{"", ""},
+		{"plain", "plain"},
+		{"\033[1;31mx\033[0m", "\033[0;31;1mx\033[m"},
+		{"\033[1m\033[31mab\033[0m", "\033[1;31ma\033[0m\033[31;1mb"},
+		{"\033]8;;http://x/\033\\a\033]8;;\033\\",
+			"\033]8;;http://x/\aa\033]8;;\a"},
+		{"a\033[2Kb", "ab"},
+	} {
+		assert.Equal(t, "", Diff(eq[0], eq[1]), "%q", eq)
+	}
+	assert.Equal(t, ""+
+		"visible text differs:\n"+
+		"\twant: \"cats\"\n"+
+		"\tgot:  \"dogs\"\n"+
+		"inspect:\n"+
+		"\twant: [red]cats[/]\n"+
+		"\tgot:  dogs\n",
+		Diff("\033[31mcats\033[0m", "dogs"))
+	assert.Equal(t, ""+
+		"style differs at runes [4:8] \"cats\":\n"+
+		"\twant: bold blue\n"+
+		"\tgot:  blue\n"+
+		"style differs at runes [9:13] \"docs\":\n"+
+		"\twant: link=http://x/ id=1\n"+
+		"\tgot:  plain\n"+
+		"inspect:\n"+
+		"\twant: got [bold blue]cats[/] [link=http://x/ id=1]docs[/link]\n"+
+		"\tgot:  got [blue]cats[/] docs\n",
+		Diff("got \033[1;34mcats\033[0m \033]8;id=1;http://x/\033\\docs\033]8;;\033\\",
+			"got \033[34mcats\033[0m docs"))
+}
+
+func TestEqual(t *testing.T) {
+	var ft fakeT
+	assert.True(t, Equal(&ft, "\033[1;31mx\033[0m", "\033[31;1mx\033[0m"))
+	assert.Empty(t, ft.errors)
+	assert.False(t, Equal(&ft, "\033[31mx\033[0m", "x"))
+	require.Len(t, ft.errors, 1)
+	assert.Contains(t, ft.errors[0], "styled output mismatch")
+	// real
+	var au = aurora.New()
+	Equal(t, "\033[0;1;31merror\033[0m", au.Bold(au.Red("error")).String())
+}
+
+func TestGolden(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "testdata", "x.golden")
+		ft   fakeT
+	)
+	assert.False(t, Golden(&ft, path, "x"))
+	assert.True(t, ft.fatal)
+	assert.Contains(t, ft.errors[0], "not found")
+	// update
+	*update = true
+	defer func() { *update = false }()
+	ft = fakeT{}
+	assert.True(t, Golden(&ft, path, "\033[31mx\033[0m"))
+	assert.Empty(t, ft.errors)
+	var data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "\033[31mx\033[0m", string(data))
+	// compare
+	*update = false
+	assert.True(t, Golden(&ft, path, "\033[0;31mx\033[m"))
+	assert.Empty(t, ft.errors)
+	assert.False(t, Golden(&ft, path, "x"))
+	require.Len(t, ft.errors, 1)
+	assert.Contains(t, ft.errors[0], "mismatch with "+path)
+}
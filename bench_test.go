@@ -36,6 +36,7 @@
 package aurora
 
 import (
+	"io"
 	"testing"
 )
 
@@ -219,6 +220,27 @@ func benchValueString(b *testing.B, vals []Value) {
 	b.ReportAllocs()
 }
 
+func benchValueAppendTo(b *testing.B, vals []Value) {
+	var buf = make([]byte, 0, 256)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < len(vals); j++ {
+			buf = vals[j].AppendTo(buf[:0])
+		}
+	}
+	b.ReportAllocs()
+}
+
+func benchValueWriteTo(b *testing.B, vals []Value) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < len(vals); j++ {
+			vals[j].WriteTo(io.Discard) //nolint
+		}
+	}
+	b.ReportAllocs()
+}
+
 func benchSprintf(b *testing.B, a *Aurora, format interface{},
 	args ...interface{}) {
 	b.ResetTimer()
@@ -266,6 +288,25 @@ func auroraBench(a *Aurora, b *testing.B) {
 		})
 	})
 
+	// a.Red("...").BgRed().Bold().Inverse().AppendTo(buf)
+	b.Run("complex value append", func(b *testing.B) {
+		b.Run("short", func(b *testing.B) {
+			benchValueAppendTo(b, complexValues(a, short))
+		})
+		b.Run("long", func(b *testing.B) {
+			benchValueAppendTo(b, complexValues(a, long))
+		})
+	})
+	// a.Red("...").BgRed().Bold().Inverse().WriteTo(w)
+	b.Run("complex value write", func(b *testing.B) {
+		b.Run("short", func(b *testing.B) {
+			benchValueWriteTo(b, complexValues(a, short))
+		})
+		b.Run("long", func(b *testing.B) {
+			benchValueWriteTo(b, complexValues(a, long))
+		})
+	})
+
 	// Sprintf
 
 	b.Run("sprintf", func(b *testing.B) {
@@ -495,3 +536,19 @@ func Benchmark_wrap(b *testing.B) {
 		b.ReportAllocs()
 	})
 }
+
+func BenchmarkColor_Sequence(b *testing.B) {
+	var c = Color(0).Index(200).BgGray(5).Bold().Framed()
+	b.Run("nos", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			gStr = esc + c.Nos(false) + "m"
+		}
+		b.ReportAllocs()
+	})
+	b.Run("sequence", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			gStr = c.Sequence()
+		}
+		b.ReportAllocs()
+	})
+}
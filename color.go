@@ -35,32 +35,37 @@
 
 package aurora
 
+import "strconv"
+
 // A Color type is a color. It can contain
 // one background color, one foreground color
 // and a format, including ideogram related
 // formats.
-type Color uint
+type Color uint64
 
 /*
 
 	Developer note.
 
-	The uint type is architecture depended and can be
-	represented as int32 or int64.
-
-	Thus, we can use 32-bits only to be fast and
-	cross-platform.
+	The Color is 64-bit wide on all platforms.
 
-	All supported formats requires 14 bits. It is
+	Common formats requires 14 bits. It is
 	first 14 bits.
 
 	A foreground color requires 8 bit + 1 bit (presence flag).
 	And the same for background color.
 
-	The Color representations
+	The lower 32 bits
 
 	[ bg 8 bit ] [fg 8 bit ] [ fg/bg 2 bits ] [ fm 14 bits ]
 
+	The upper 32 bits contain extended formats, like curly underline,
+	underline color, and alternative font. Upper 4 bits are reserved
+	for internal use.
+
+	[ reserved 4 bit ] [ unused 4 bit ] [ fm 8 bit ] [ font 4 bit ]
+	    [ ul 8 bit ] [ ul 1 bit ] [ ul styles 3 bit ]
+
 	https://play.golang.org/p/fq2zcNstFoF
 
 */
@@ -97,7 +102,11 @@ const (
 
 		FrakturFm | DoublyUnderlineFm |
 
-		FramedFm | EncircledFm | OverlinedFm
+		FramedFm | EncircledFm | OverlinedFm |
+
+		maskUlStyle |
+
+		ProportionalSpacingFm | maskIdeogram | maskScript
 
 	flagFg Color = 1 << 14 // presence flag (14th bit)
 	flagBg Color = 1 << 15 // presence flag (15th bit)
@@ -106,6 +115,48 @@ const (
 	shiftBg = 24 // shift for background (starting from 24th bit)
 )
 
+// Underline styles, not widely supported; a terminal that doesn't support
+// them may ignore them or may show plain underline
+const (
+	CurlyUnderlineFm  Color = 1 << (32 + iota) // 4:3
+	DottedUnderlineFm                          // 4:4
+	DashedUnderlineFm                          // 4:5
+
+	maskUlStyle = CurlyUnderlineFm | DottedUnderlineFm | DashedUnderlineFm
+
+	// all underline formats
+	maskUnderline = UnderlineFm | DoublyUnderlineFm | maskUlStyle
+
+	flagUl Color = 1 << 35 // underline color presence flag (35th bit)
+
+	shiftUl = 36 // shift for underline color (starting from 36th bit)
+
+	// 8 bits
+	maskUl = (0xff << shiftUl) | flagUl
+)
+
+// Other ECMA-48 formats, rarely supported
+const (
+	ProportionalSpacingFm     Color = 1 << (48 + iota) // 26
+	IdeogramUnderlineFm                                // 60
+	IdeogramDoubleUnderlineFm                          // 61
+	IdeogramOverlineFm                                 // 62
+	IdeogramDoubleOverlineFm                           // 63
+	IdeogramStressFm                                   // 64
+	SuperscriptFm                                      // 73
+	SubscriptFm                                        // 74
+
+	maskIdeogram = IdeogramUnderlineFm | IdeogramDoubleUnderlineFm |
+		IdeogramOverlineFm | IdeogramDoubleOverlineFm | IdeogramStressFm
+
+	maskScript = SuperscriptFm | SubscriptFm
+
+	shiftFont = 44 // shift for alternative font (starting from 44th bit)
+
+	// 4 bits, where zero is the primary font
+	maskFont Color = 0xf << shiftFont
+)
+
 // Foreground colors and related formats
 const (
 
@@ -172,6 +223,120 @@ const (
 	clear      = esc + "0m"
 )
 
+// Go names of formats
+var formatGoNames = [...]struct {
+	fm   Color
+	name string
+}{
+	{BoldFm, "BoldFm"},
+	{FaintFm, "FaintFm"},
+	{ItalicFm, "ItalicFm"},
+	{UnderlineFm, "UnderlineFm"},
+	{SlowBlinkFm, "SlowBlinkFm"},
+	{RapidBlinkFm, "RapidBlinkFm"},
+	{ReverseFm, "ReverseFm"},
+	{ConcealFm, "ConcealFm"},
+	{CrossedOutFm, "CrossedOutFm"},
+	{FrakturFm, "FrakturFm"},
+	{DoublyUnderlineFm, "DoublyUnderlineFm"},
+	{FramedFm, "FramedFm"},
+	{EncircledFm, "EncircledFm"},
+	{OverlinedFm, "OverlinedFm"},
+	{CurlyUnderlineFm, "CurlyUnderlineFm"},
+	{DottedUnderlineFm, "DottedUnderlineFm"},
+	{DashedUnderlineFm, "DashedUnderlineFm"},
+	{ProportionalSpacingFm, "ProportionalSpacingFm"},
+	{IdeogramUnderlineFm, "IdeogramUnderlineFm"},
+	{IdeogramDoubleUnderlineFm, "IdeogramDoubleUnderlineFm"},
+	{IdeogramOverlineFm, "IdeogramOverlineFm"},
+	{IdeogramDoubleOverlineFm, "IdeogramDoubleOverlineFm"},
+	{IdeogramStressFm, "IdeogramStressFm"},
+	{SuperscriptFm, "SuperscriptFm"},
+	{SubscriptFm, "SubscriptFm"},
+}
+
+// Go names of standard colors without Fg or Bg suffix
+var colorGoNames = [...]string{
+	"Black", "Red", "Green", "Yellow", "Blue", "Magenta", "Cyan", "White",
+}
+
+// appendGoNames appends names of constants and methods the Color consists
+// of, separated by the '|', where every name is prefixed with given prefix
+func (c Color) appendGoNames(bs []byte, prefix string) []byte {
+	var (
+		start = len(bs)
+		known Color
+	)
+	var add = func(names ...string) {
+		for _, name := range names {
+			if len(bs) > start {
+				bs = append(bs, '|')
+			}
+			bs = append(bs, prefix...)
+			bs = append(bs, name...)
+		}
+	}
+	for _, fn := range formatGoNames {
+		if c&fn.fm != 0 {
+			add(fn.name)
+		}
+	}
+	known |= maskFm
+	var method string // Index and Gray methods are called on zero Color
+	if prefix != "" {
+		method = "Color(0)."
+	}
+	if c&maskFont != 0 {
+		known |= maskFont
+		add(method + "Font(" + strconv.Itoa(int((c&maskFont)>>shiftFont)) + ")")
+	}
+	var color = func(mask, flag Color, shift int, suffix, index, gray string) {
+		if c&flag == 0 {
+			return
+		}
+		known |= mask
+		var n = uint8((c & mask) >> shift)
+		switch {
+		case n <= 7:
+			add(colorGoNames[n] + suffix)
+		case n <= 15:
+			add("Bright"+suffix, colorGoNames[n-8]+suffix)
+		case n >= 232:
+			add(method + gray + "(" + strconv.Itoa(int(n-232)) + ")")
+		default:
+			add(method + index + "(" + strconv.Itoa(int(n)) + ")")
+		}
+	}
+	color(maskFg, flagFg, shiftFg, "Fg", "Index", "Gray")
+	color(maskBg, flagBg, shiftBg, "Bg", "BgIndex", "BgGray")
+	if c&flagUl != 0 {
+		known |= maskUl
+		var n = uint8((c & maskUl) >> shiftUl)
+		if n >= 232 {
+			add(method + "UnderlineGray(" + strconv.Itoa(int(n-232)) + ")")
+		} else {
+			add(method + "UnderlineIndex(" + strconv.Itoa(int(n)) + ")")
+		}
+	}
+	if rest := c &^ known; rest != 0 || len(bs) == start {
+		add("Color(0x" + strconv.FormatUint(uint64(rest), 16) + ")")
+	}
+	return bs
+}
+
+// String returns names of constants and methods the Color consists of,
+// like "BoldFm|RedFg|BlueBg", "Index(100)|BgGray(5)", or "Color(0x0)" for
+// zero Color.
+func (c Color) String() string {
+	return string(c.appendGoNames(make([]byte, 0, 32), ""))
+}
+
+// GoString returns Go representation of the Color, like
+// "aurora.BoldFm|aurora.RedFg" or "aurora.Color(0).Index(100)".
+func (c Color) GoString() string {
+	return string(c.appendGoNames(make([]byte, 0, 48), "aurora."))
+}
+
 // Nos returns string like 1;7;31;45. It
 // may be an empty string for empty color.
 // If the zero is true, then the string
@@ -301,6 +466,8 @@ func (c Color) appendFm9(bs []byte, zero bool) []byte {
 // and colors
 func (c Color) appendNos(bs []byte, zero bool) []byte {
 
+	var start = len(bs)
+
 	if zero {
 		bs = append(bs, '0') // reset previous
 	}
@@ -365,6 +532,35 @@ func (c Color) appendNos(bs []byte, zero bool) []byte {
 				'5', '3')
 		}
 
+		// 4:3-4:5, only one of them, preferring curly
+
+		switch {
+		case c&CurlyUnderlineFm != 0:
+			bs = appendSemi(bs, len(bs) > start, '4', ':', '3')
+		case c&DottedUnderlineFm != 0:
+			bs = appendSemi(bs, len(bs) > start, '4', ':', '4')
+		case c&DashedUnderlineFm != 0:
+			bs = appendSemi(bs, len(bs) > start, '4', ':', '5')
+		}
+
+		// 26, 60-64, 73-74
+
+		bs = appendCond(bs, c&ProportionalSpacingFm != 0, len(bs) > start,
+			'2', '6')
+		if c&maskIdeogram != 0 {
+			for i, fm := 0, IdeogramUnderlineFm; fm&maskIdeogram != 0; i, fm =
+				i+1, fm<<1 {
+
+				bs = appendCond(bs, c&fm != 0, len(bs) > start, '6', '0'+byte(i))
+			}
+		}
+		// don't combine superscript and subscript, preferring superscript
+		if c&SuperscriptFm != 0 {
+			bs = appendSemi(bs, len(bs) > start, '7', '3')
+		} else if c&SubscriptFm != 0 {
+			bs = appendSemi(bs, len(bs) > start, '7', '4')
+		}
+
 	}
 
 	// foreground
@@ -377,6 +573,18 @@ func (c Color) appendNos(bs []byte, zero bool) []byte {
 		bs = c.appendBg(bs, zero)
 	}
 
+	// underline color
+	if c&flagUl != 0 {
+		bs = appendSemi(bs, len(bs) > start, '5', '8', ';', '5', ';')
+		bs = append(bs, itoa(byte((c&maskUl)>>shiftUl))...)
+	}
+
+	// alternative font
+	if c&maskFont != 0 {
+		bs = appendSemi(bs, len(bs) > start,
+			'1', '0'+byte((c&maskFont)>>shiftFont))
+	}
+
 	return bs
 }
 
@@ -392,6 +600,10 @@ type ColorIndex uint8
 // GrayIndex from 0 to 23.
 type GrayIndex uint8
 
+// FontIndex is index of alternative font from 1 to 9 (11-19). The zero
+// is the primary font (10).
+type FontIndex uint8
+
 // The Colored interface represents a value with a Color.
 type Colored interface {
 	Color() Color // color of the value
@@ -402,6 +614,156 @@ func (c Color) Reset() Color {
 	return Color(0)
 }
 
+// Merge returns Color with formats of both colors. Foreground and
+// background colors of the over Color replace foreground and background
+// of the c, if present.
+func (c Color) Merge(over Color) Color {
+	if over&flagFg != 0 {
+		c &^= maskFg
+	}
+	if over&flagBg != 0 {
+		c &^= maskBg
+	}
+	if over&flagUl != 0 {
+		c &^= maskUl
+	}
+	if over&maskFont != 0 {
+		c &^= maskFont
+	}
+	return c | over
+}
+
+//
+// Accessors
+//
+
+// A Format is a single format of a Color, like BoldFm.
+type Format Color
+
+// String returns name of the Format, like "bold".
+func (f Format) String() string {
+	for _, fn := range formatNames {
+		if fn.fm == Color(f) {
+			return fn.name
+		}
+	}
+	return Color(f).String()
+}
+
+// Color of the Format.
+func (f Format) Color() Color {
+	return Color(f)
+}
+
+// Formats of the Color, like bold or underline, in order of their SGR
+// codes. Aliases, like BlinkFm, are not listed separately.
+func (c Color) Formats() (fms []Format) {
+	for fm := BoldFm; fm != 0; fm <<= 1 {
+		if c&fm&maskFm != 0 {
+			fms = append(fms, Format(fm))
+		}
+	}
+	return
+}
+
+// Foreground color index of the Color, if the Color has foreground. Both
+// standard colors, like RedFg, and bright ones, like BrightFg|RedFg, are
+// represented as indices from 0 to 15.
+func (c Color) Foreground() (n ColorIndex, ok bool) {
+	if c&flagFg == 0 {
+		return
+	}
+	return ColorIndex((c & maskFg) >> shiftFg), true
+}
+
+// Background color index of the Color, if the Color has background. See
+// Foreground for details.
+func (c Color) Background() (n ColorIndex, ok bool) {
+	if c&flagBg == 0 {
+		return
+	}
+	return ColorIndex((c & maskBg) >> shiftBg), true
+}
+
+// ForegroundGray returns gray index from 0 to 23 of foreground of the
+// Color, if the foreground is one of Gray colors (232-255).
+func (c Color) ForegroundGray() (n GrayIndex, ok bool) {
+	var fg ColorIndex
+	if fg, ok = c.Foreground(); !ok || fg < 232 {
+		return 0, false
+	}
+	return GrayIndex(fg - 232), true
+}
+
+// BackgroundGray returns gray index from 0 to 23 of background of the
+// Color, if the background is one of BgGray colors (232-255).
+func (c Color) BackgroundGray() (n GrayIndex, ok bool) {
+	var bg ColorIndex
+	if bg, ok = c.Background(); !ok || bg < 232 {
+		return 0, false
+	}
+	return GrayIndex(bg - 232), true
+}
+
+// UnderlineColor returns color index of underline color of the Color, if
+// the Color has underline color.
+func (c Color) UnderlineColor() (n ColorIndex, ok bool) {
+	if c&flagUl == 0 {
+		return
+	}
+	return ColorIndex((c & maskUl) >> shiftUl), true
+}
+
+// AlternativeFont returns index of alternative font of the Color, if the
+// Color has one.
+func (c Color) AlternativeFont() (n FontIndex, ok bool) {
+	if n = FontIndex((c & maskFont) >> shiftFont); n == 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// IsBright reports whether foreground of the Color is one of bright
+// colors (90-97), like BrightRed.
+func (c Color) IsBright() bool {
+	var fg, ok = c.Foreground()
+	return ok && fg >= 8 && fg <= 15
+}
+
+// IsBgBright reports whether background of the Color is one of bright
+// colors (100-107), like BgBrightRed.
+func (c Color) IsBgBright() bool {
+	var bg, ok = c.Background()
+	return ok && bg >= 8 && bg <= 15
+}
+
+// Has reports whether the Color has all formats of given Color, and the
+// same foreground and background colors, if the given Color has them.
+// For example
+//
+//	(BoldFm | RedFg).Has(BoldFm)          // true
+//	(BoldFm | RedFg).Has(RedFg)           // true
+//	(BoldFm | RedFg).Has(BoldFm | BlueFg) // false
+//	RedFg.Has(0)                          // true
+func (c Color) Has(x Color) bool {
+	if c&x&maskFm != x&maskFm {
+		return false
+	}
+	if x&flagFg != 0 && c&maskFg != x&maskFg {
+		return false
+	}
+	if x&flagBg != 0 && c&maskBg != x&maskBg {
+		return false
+	}
+	if x&flagUl != 0 && c&maskUl != x&maskUl {
+		return false
+	}
+	if x&maskFont != 0 && c&maskFont != x&maskFont {
+		return false
+	}
+	return true
+}
+
 //
 // Formats
 //
@@ -419,7 +781,7 @@ func (c Color) Faint() Color {
 // DoublyUnderline or Bold off, double-underline
 // per ECMA-48 (21).
 func (c Color) DoublyUnderline() Color {
-	return (c &^ UnderlineFm) | DoublyUnderlineFm
+	return (c &^ maskUnderline) | DoublyUnderlineFm
 }
 
 // Fraktur, rarely supported (20).
@@ -435,7 +797,7 @@ func (c Color) Italic() Color {
 
 // Underline (4).
 func (c Color) Underline() Color {
-	return (c &^ DoublyUnderlineFm) | UnderlineFm
+	return (c &^ maskUnderline) | UnderlineFm
 }
 
 // SlowBlink, blinking less than 150
@@ -502,6 +864,75 @@ func (c Color) Overlined() Color {
 	return c | OverlinedFm
 }
 
+// Curly underline (4:3), not widely supported. It replaces other
+// underline formats.
+func (c Color) CurlyUnderline() Color {
+	return (c &^ maskUnderline) | CurlyUnderlineFm
+}
+
+// Dotted underline (4:4), not widely supported. It replaces other
+// underline formats.
+func (c Color) DottedUnderline() Color {
+	return (c &^ maskUnderline) | DottedUnderlineFm
+}
+
+// Dashed underline (4:5), not widely supported. It replaces other
+// underline formats.
+func (c Color) DashedUnderline() Color {
+	return (c &^ maskUnderline) | DashedUnderlineFm
+}
+
+// Proportional spacing, rarely supported (26).
+func (c Color) ProportionalSpacing() Color {
+	return c | ProportionalSpacingFm
+}
+
+// IdeogramUnderline or right side line, rarely supported (60).
+func (c Color) IdeogramUnderline() Color {
+	return c | IdeogramUnderlineFm
+}
+
+// IdeogramDoubleUnderline or double line on the right side, rarely
+// supported (61).
+func (c Color) IdeogramDoubleUnderline() Color {
+	return c | IdeogramDoubleUnderlineFm
+}
+
+// IdeogramOverline or left side line, rarely supported (62).
+func (c Color) IdeogramOverline() Color {
+	return c | IdeogramOverlineFm
+}
+
+// IdeogramDoubleOverline or double line on the left side, rarely
+// supported (63).
+func (c Color) IdeogramDoubleOverline() Color {
+	return c | IdeogramDoubleOverlineFm
+}
+
+// IdeogramStress marking, rarely supported (64).
+func (c Color) IdeogramStress() Color {
+	return c | IdeogramStressFm
+}
+
+// Superscript, reset the Subscript, rarely supported (73).
+func (c Color) Superscript() Color {
+	return (c &^ SubscriptFm) | SuperscriptFm
+}
+
+// Subscript, reset the Superscript, rarely supported (74).
+func (c Color) Subscript() Color {
+	return (c &^ SuperscriptFm) | SubscriptFm
+}
+
+// Font sets alternative font from 1 to 9 (11-19), rarely supported. The
+// zero is the primary font (10).
+func (c Color) Font(n FontIndex) Color {
+	if n > 9 {
+		n = 9
+	}
+	return (c &^ maskFont) | (Color(n) << shiftFont)
+}
+
 // Foreground colors
 //
 // Black foreground color (30)
@@ -607,6 +1038,12 @@ func (c Color) Gray(n GrayIndex) Color {
 	return (c &^ maskFg) | (Color(232+n) << shiftFg) | flagFg
 }
 
+// TrueColor sets nearest 8-bit foreground color of given 24-bit one, see
+// RGB.Index. A Color can't keep 24-bit colors, but Values and Styles can.
+func (c Color) TrueColor(rgb RGB) Color {
+	return c.Index(rgb.Index())
+}
+
 // Background colors
 //
 // BgBlack background color (40)
@@ -711,3 +1148,146 @@ func (c Color) BgGray(n GrayIndex) Color {
 	}
 	return (c &^ maskBg) | (Color(232+n) << shiftBg) | flagBg
 }
+
+// BgTrueColor sets nearest 8-bit background color of given 24-bit one.
+// See TrueColor.
+func (c Color) BgTrueColor(rgb RGB) Color {
+	return c.BgIndex(rgb.Index())
+}
+
+// Underline color
+
+// UnderlineIndex sets underline color, 8-bit pre-defined color from 0 to
+// 255 (58;5;n), not widely supported. See Index for details.
+func (c Color) UnderlineIndex(n ColorIndex) Color {
+	return (c &^ maskUl) | (Color(n) << shiftUl) | flagUl
+}
+
+// UnderlineGray sets gray underline color from 0 to 23.
+func (c Color) UnderlineGray(n GrayIndex) Color {
+	if n > 23 {
+		n = 23
+	}
+	return (c &^ maskUl) | (Color(232+n) << shiftUl) | flagUl
+}
+
+//
+// Removal
+//
+
+// NoBold removes Bold format.
+func (c Color) NoBold() Color {
+	return c &^ BoldFm
+}
+
+// NoFaint removes Faint format.
+func (c Color) NoFaint() Color {
+	return c &^ FaintFm
+}
+
+// NoItalic removes Italic format.
+func (c Color) NoItalic() Color {
+	return c &^ ItalicFm
+}
+
+// NoUnderline removes all underline formats, including curly, dotted and
+// dashed ones, but not underline color.
+func (c Color) NoUnderline() Color {
+	return c &^ maskUnderline
+}
+
+// NoBlink removes SlowBlink and RapidBlink formats.
+func (c Color) NoBlink() Color {
+	return c &^ (SlowBlinkFm | RapidBlinkFm)
+}
+
+// NoReverse removes Reverse format.
+func (c Color) NoReverse() Color {
+	return c &^ ReverseFm
+}
+
+// NoConceal removes Conceal format.
+func (c Color) NoConceal() Color {
+	return c &^ ConcealFm
+}
+
+// NoCrossedOut removes CrossedOut format.
+func (c Color) NoCrossedOut() Color {
+	return c &^ CrossedOutFm
+}
+
+// NoFraktur removes Fraktur format.
+func (c Color) NoFraktur() Color {
+	return c &^ FrakturFm
+}
+
+// NoFramed removes Framed format.
+func (c Color) NoFramed() Color {
+	return c &^ FramedFm
+}
+
+// NoEncircled removes Encircled format.
+func (c Color) NoEncircled() Color {
+	return c &^ EncircledFm
+}
+
+// NoOverlined removes Overlined format.
+func (c Color) NoOverlined() Color {
+	return c &^ OverlinedFm
+}
+
+// NoForeground removes foreground color.
+func (c Color) NoForeground() Color {
+	return c &^ maskFg
+}
+
+// NoBackground removes background color.
+func (c Color) NoBackground() Color {
+	return c &^ maskBg
+}
+
+// NoUnderlineColor removes underline color.
+func (c Color) NoUnderlineColor() Color {
+	return c &^ maskUl
+}
+
+// NoProportionalSpacing removes ProportionalSpacing format.
+func (c Color) NoProportionalSpacing() Color {
+	return c &^ ProportionalSpacingFm
+}
+
+// NoIdeograms removes all ideogram formats.
+func (c Color) NoIdeograms() Color {
+	return c &^ maskIdeogram
+}
+
+// NoScript removes Superscript and Subscript formats.
+func (c Color) NoScript() Color {
+	return c &^ maskScript
+}
+
+// NoFont removes alternative font, using the primary one.
+func (c Color) NoFont() Color {
+	return c &^ maskFont
+}
+
+// Without returns the Color without formats of given Color, without
+// foreground color if given Color has foreground, and without background,
+// underline color or font if given Color has them. For example
+//
+//	(BoldFm | ItalicFm | RedFg | BlueBg).Without(BoldFm | BlackFg) // ItalicFm | BlueBg
+func (c Color) Without(x Color) Color {
+	if x&flagFg != 0 {
+		c &^= maskFg
+	}
+	if x&flagBg != 0 {
+		c &^= maskBg
+	}
+	if x&flagUl != 0 {
+		c &^= maskUl
+	}
+	if x&maskFont != 0 {
+		c &^= maskFont
+	}
+	return c &^ (x & maskFm)
+}
@@ -36,8 +36,11 @@
 package aurora
 
 import (
+	"fmt"
 	"math"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -151,6 +154,95 @@ func TestColor_Nos(t *testing.T) {
 	}
 }
 
+func TestColor_Sequence(t *testing.T) {
+	assert.Equal(t, "", Color(0).Sequence())
+	assert.Equal(t, "\033[0m", Color(0).zeroSequence())
+	for _, c := range []Color{
+		BoldFm,
+		RedFg | BlueBg | ItalicFm,
+		Color(0).Index(200).BgGray(5).Framed(),
+	} {
+		assert.Equal(t, esc+c.Nos(false)+"m", c.Sequence())
+		assert.Equal(t, esc+c.Nos(true)+"m", c.zeroSequence())
+		// cached
+		assert.Equal(t, esc+c.Nos(false)+"m", c.Sequence())
+		assert.Zero(t, testing.AllocsPerRun(10, func() { _ = c.Sequence() }))
+	}
+	// concurrent access
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 256; j++ {
+				var c = Color(0).Index(ColorIndex(j)).BgIndex(ColorIndex(i))
+				assert.Equal(t, esc+c.Nos(false)+"m", c.Sequence())
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func Test_sequenceCache(t *testing.T) {
+	var cache = newSequenceCache(false)
+	for i := 0; i < maxCachedSequences+100; i++ {
+		var c = Color(0).Index(ColorIndex(i)).BgIndex(ColorIndex(i >> 8))
+		assert.Equal(t, esc+c.Nos(false)+"m", cache.get(c))
+		assert.Equal(t, esc+c.Nos(false)+"m", cache.get(c)) // cached or not
+	}
+	assert.Equal(t, int64(maxCachedSequences), cache.size.Load())
+}
+
+func TestColor_Transition(t *testing.T) {
+	for _, tt := range []struct {
+		from, to Color
+		want     string
+	}{
+		{RedFg, RedFg, ""},
+		{BoldFm | FaintFm, BoldFm, ""},
+		{0, BoldFm | RedFg, "\033[1;31m"},
+		{RedFg, BlueFg, "\033[34m"},
+		{RedFg | BoldFm, BlueFg, "\033[0;34m"},
+		{RedFg | BoldFm | ItalicFm, BoldFm | RedFg, "\033[23m"},
+		{BoldFm | RedFg, FaintFm | RedFg, "\033[22;2m"},
+		{ItalicFm | FrakturFm | RedFg, FrakturFm | RedFg, "\033[23;20m"},
+		{BoldFm, FaintFm, "\033[0;2m"},
+		{UnderlineFm | RedFg | BlueBg, RedFg | BlueBg, "\033[24m"},
+		{RapidBlinkFm | GreenFg, SlowBlinkFm | GreenFg, "\033[25;5m"},
+		{ReverseFm | RedFg, RedFg, "\033[27m"},
+		{ConcealFm | RedFg, RedFg, "\033[28m"},
+		{CrossedOutFm | RedFg, RedFg, "\033[29m"},
+		{EncircledFm | RedFg, RedFg, "\033[54m"},
+		{OverlinedFm | RedFg, RedFg, "\033[55m"},
+		{BoldFm | RedFg | BlueBg, BoldFm | BlueBg, "\033[39m"},
+		{BoldFm | RedFg | BlueBg, BoldFm | RedFg, "\033[49m"},
+		{BoldFm | RedFg, BoldFm | Color(0).Index(100), "\033[38;5;100m"},
+		{RedFg, 0, "\033[0m"},
+	} {
+		assert.Equal(t, tt.want, tt.from.Transition(tt.to),
+			"%v -> %v", tt.from, tt.to)
+	}
+	// applying the transition gives the target color
+	var colors = []Color{0, BoldFm, FaintFm, BoldFm | ItalicFm | RedFg,
+		FrakturFm | DoublyUnderlineFm | BlueBg, SlowBlinkFm | ReverseFm,
+		RapidBlinkFm | ConcealFm | CrossedOutFm | Color(0).Index(100),
+		FramedFm | OverlinedFm | Color(0).BgGray(5), EncircledFm | WhiteBg,
+		UnderlineFm | BrightFg | GreenFg | BrightBg | RedBg}
+	for _, from := range colors {
+		for _, to := range colors {
+			var tr = from.Transition(to)
+			if tr == "" {
+				assert.Equal(t, from.effective(), to.effective())
+				continue
+			}
+			var params = strings.TrimSuffix(strings.TrimPrefix(tr, esc), "m")
+			assert.Equal(t, to.effective(),
+				from.ApplySGR(params).effective(), "%v -> %v", from, to)
+			assert.LessOrEqual(t, len(tr), len(to.zeroSequence()))
+		}
+	}
+}
+
 func Test_itoa(t *testing.T) {
 	for i := 0; i < 256; i++ {
 		var a = itoa(byte(i))
@@ -163,6 +255,274 @@ func TestColor_Reset(t *testing.T) {
 	assert.Zero(t, c.Reset())
 }
 
+func TestColor_String(t *testing.T) {
+	for c, want := range map[Color]string{
+		0:                              "Color(0x0)",
+		BoldFm | RedFg | BlueBg:        "BoldFm|RedFg|BlueBg",
+		maskFm:                         "BoldFm|FaintFm|ItalicFm|UnderlineFm|SlowBlinkFm|RapidBlinkFm|ReverseFm|ConcealFm|CrossedOutFm|FrakturFm|DoublyUnderlineFm|FramedFm|EncircledFm|OverlinedFm|CurlyUnderlineFm|DottedUnderlineFm|DashedUnderlineFm|ProportionalSpacingFm|IdeogramUnderlineFm|IdeogramDoubleUnderlineFm|IdeogramOverlineFm|IdeogramDoubleOverlineFm|IdeogramStressFm|SuperscriptFm|SubscriptFm",
+		RedFg | BrightFg | BlackBg:     "BrightFg|RedFg|BlackBg",
+		Color(0).Index(100).BgGray(5):  "Index(100)|BgGray(5)",
+		Color(0).Gray(23).BgIndex(200): "Gray(23)|BgIndex(200)",
+		Color(0).BgIndex(15):           "BrightBg|WhiteBg",
+		Color(1 << shiftFg):            "Color(0x10000)",
+		ItalicFm | BrightFg:            "ItalicFm|BrightFg|BlackFg",
+	} {
+		assert.Equal(t, want, c.String())
+		assert.Equal(t, want, fmt.Sprint(c))
+	}
+}
+
+func TestColor_GoString(t *testing.T) {
+	for c, want := range map[Color]string{
+		0:                             "aurora.Color(0x0)",
+		BoldFm | RedFg | BlueBg:       "aurora.BoldFm|aurora.RedFg|aurora.BlueBg",
+		RedFg | BrightFg:              "aurora.BrightFg|aurora.RedFg",
+		Color(0).Index(100).BgGray(5): "aurora.Color(0).Index(100)|aurora.Color(0).BgGray(5)",
+		BoldFm | Color(0).Index(16):   "aurora.BoldFm|aurora.Color(0).Index(16)",
+	} {
+		assert.Equal(t, want, c.GoString())
+		assert.Equal(t, want, fmt.Sprintf("%#v", c))
+	}
+}
+
+func TestColor_Merge(t *testing.T) {
+	assert.Equal(t, RedFg|BoldFm, RedFg.Merge(BoldFm))
+	assert.Equal(t, BlueFg|BoldFm|ItalicFm, (RedFg | BoldFm).Merge(BlueFg|ItalicFm))
+	assert.Equal(t, RedFg|BlueBg, (RedFg | GreenBg).Merge(BlueBg))
+	assert.Equal(t, Color(0).Index(100).BgGray(3),
+		Color(0).Index(100).BgIndex(10).Merge(Color(0).BgGray(3)))
+	assert.Equal(t, RedFg, RedFg.Merge(0))
+}
+
+func TestColor_removal(t *testing.T) {
+	var c = BoldFm | FaintFm | ItalicFm | UnderlineFm | DoublyUnderlineFm |
+		SlowBlinkFm | RapidBlinkFm | ReverseFm | ConcealFm | CrossedOutFm |
+		FrakturFm | FramedFm | EncircledFm | OverlinedFm | RedFg | BlueBg
+	assert.Equal(t, c&^BoldFm, c.NoBold())
+	assert.Equal(t, c&^FaintFm, c.NoFaint())
+	assert.Equal(t, c&^ItalicFm, c.NoItalic())
+	assert.Equal(t, c&^(UnderlineFm|DoublyUnderlineFm), c.NoUnderline())
+	assert.Equal(t, c&^(SlowBlinkFm|RapidBlinkFm), c.NoBlink())
+	assert.Equal(t, c&^ReverseFm, c.NoReverse())
+	assert.Equal(t, c&^ConcealFm, c.NoConceal())
+	assert.Equal(t, c&^CrossedOutFm, c.NoCrossedOut())
+	assert.Equal(t, c&^FrakturFm, c.NoFraktur())
+	assert.Equal(t, c&^FramedFm, c.NoFramed())
+	assert.Equal(t, c&^EncircledFm, c.NoEncircled())
+	assert.Equal(t, c&^OverlinedFm, c.NoOverlined())
+	assert.Equal(t, c&^RedFg, c.NoForeground())
+	assert.Equal(t, c&^BlueBg, c.NoBackground())
+	assert.Equal(t, BlueBg, Color(0).Index(100).BgIndex(4).NoForeground().
+		Merge(BlueBg))
+	assert.Equal(t, Color(0).Index(100), Color(0).Index(100).BgGray(3).
+		NoBackground())
+	assert.Equal(t, Color(0), Color(0).NoBold().NoForeground())
+}
+
+func TestColor_underline(t *testing.T) {
+	// styles are exclusive
+	assert.Equal(t, CurlyUnderlineFm,
+		UnderlineFm.DoublyUnderline().DottedUnderline().CurlyUnderline())
+	assert.Equal(t, DottedUnderlineFm, CurlyUnderlineFm.DottedUnderline())
+	assert.Equal(t, DashedUnderlineFm|BoldFm,
+		(CurlyUnderlineFm | BoldFm).DashedUnderline())
+	assert.Equal(t, UnderlineFm, DashedUnderlineFm.Underline())
+	assert.Equal(t, DoublyUnderlineFm, DashedUnderlineFm.DoublyUnderline())
+	assert.Equal(t, RedFg, (CurlyUnderlineFm | RedFg).NoUnderline())
+	// sequences
+	assert.Equal(t, "4:3", CurlyUnderlineFm.Nos(false))
+	assert.Equal(t, "0;4:4", DottedUnderlineFm.Nos(true))
+	assert.Equal(t, "1;4:5;31", (BoldFm | DashedUnderlineFm | RedFg).Nos(false))
+	assert.Equal(t, "4:3;31;44;58;5;100",
+		(CurlyUnderlineFm | RedFg | BlueBg).UnderlineIndex(100).Nos(false))
+	assert.Equal(t, "58;5;237", Color(0).UnderlineGray(5).Nos(false))
+	assert.Equal(t, "0;58;5;255", Color(0).UnderlineGray(100).Nos(true))
+	// underline color
+	var c = Color(0).UnderlineIndex(100).Bold()
+	var n, ok = c.UnderlineColor()
+	assert.True(t, ok)
+	assert.Equal(t, ColorIndex(100), n)
+	_, ok = c.NoUnderlineColor().UnderlineColor()
+	assert.False(t, ok)
+	assert.Equal(t, BoldFm, c.NoUnderlineColor())
+	assert.Equal(t, BoldFm, c.Without(Color(0).UnderlineIndex(0)))
+	assert.Equal(t, Color(0).UnderlineIndex(5).Bold(),
+		c.Merge(Color(0).UnderlineIndex(5)))
+	assert.True(t, c.Has(Color(0).UnderlineIndex(100)))
+	assert.False(t, c.Has(Color(0).UnderlineIndex(101)))
+	// names
+	assert.Equal(t, "BoldFm|UnderlineIndex(100)", c.String())
+	assert.Equal(t, "CurlyUnderlineFm|UnderlineGray(5)",
+		Color(0).UnderlineGray(5).CurlyUnderline().String())
+	assert.Equal(t, "aurora.BoldFm|aurora.Color(0).UnderlineIndex(100)",
+		c.GoString())
+	// transition
+	assert.Equal(t, "\033[59m", (c | RedFg).Transition(RedFg|BoldFm))
+	assert.Equal(t, "\033[24;4:3m",
+		(UnderlineFm | RedFg).Transition(CurlyUnderlineFm|RedFg))
+	assert.Equal(t, "\033[24m",
+		(CurlyUnderlineFm | RedFg | BoldFm).Transition(RedFg|BoldFm))
+}
+
+func TestColor_ecma48(t *testing.T) {
+	// formats
+	assert.Equal(t, ProportionalSpacingFm, Color(0).ProportionalSpacing())
+	assert.Equal(t, maskIdeogram, Color(0).IdeogramUnderline().
+		IdeogramDoubleUnderline().IdeogramOverline().
+		IdeogramDoubleOverline().IdeogramStress())
+	assert.Equal(t, SuperscriptFm, SubscriptFm.Superscript())
+	assert.Equal(t, SubscriptFm, SuperscriptFm.Subscript())
+	// removal
+	var c = BoldFm | ProportionalSpacingFm | maskIdeogram | SuperscriptFm
+	assert.Equal(t, c&^ProportionalSpacingFm, c.NoProportionalSpacing())
+	assert.Equal(t, c&^maskIdeogram, c.NoIdeograms())
+	assert.Equal(t, c&^SuperscriptFm, c.NoScript())
+	assert.Equal(t, SubscriptFm, SubscriptFm.NoFont())
+	// font
+	var n, ok = Color(0).AlternativeFont()
+	assert.False(t, ok)
+	n, ok = RedFg.Font(3).AlternativeFont()
+	assert.True(t, ok)
+	assert.Equal(t, FontIndex(3), n)
+	n, _ = Color(0).Font(100).AlternativeFont()
+	assert.Equal(t, FontIndex(9), n)
+	assert.Equal(t, RedFg, RedFg.Font(3).Font(0))
+	assert.Equal(t, RedFg, RedFg.Font(3).NoFont())
+	assert.Equal(t, RedFg, RedFg.Font(3).Without(Color(0).Font(1)))
+	assert.Equal(t, Color(0).Font(1), Color(0).Font(3).Merge(Color(0).Font(1)))
+	assert.Equal(t, Color(0).Font(3), Color(0).Font(3).Merge(BoldFm).NoBold())
+	assert.True(t, RedFg.Font(3).Has(Color(0).Font(3)))
+	assert.False(t, RedFg.Font(3).Has(Color(0).Font(4)))
+	// sequences
+	assert.Equal(t, "26;60;61;62;63;64;73",
+		(ProportionalSpacingFm | maskIdeogram | maskScript).Nos(false))
+	assert.Equal(t, "0;74", SubscriptFm.Nos(true))
+	assert.Equal(t, "1;31;13", BoldFm.Red().Font(3).Nos(false))
+	assert.Equal(t, "0;19", Color(0).Font(9).Nos(true))
+	// names
+	assert.Equal(t, "SuperscriptFm|Font(3)|RedFg",
+		Color(0).Superscript().Font(3).Red().String())
+	assert.Equal(t, "aurora.Color(0).Font(3)", Color(0).Font(3).GoString())
+	// transition
+	assert.Equal(t, "\033[10m", (RedFg | BoldFm).Font(3).Transition(RedFg|BoldFm))
+	assert.Equal(t, "\033[12m", (RedFg | BoldFm).Font(3).Transition(
+		(RedFg | BoldFm).Font(2)))
+	assert.Equal(t, "\033[65m", (RedFg | maskIdeogram).Transition(RedFg))
+	assert.Equal(t, "\033[75m", (RedFg | SubscriptFm).Transition(RedFg))
+	assert.Equal(t, "\033[50m", (RedFg | ProportionalSpacingFm).Transition(RedFg))
+}
+
+func TestColor_Without(t *testing.T) {
+	var c = BoldFm | ItalicFm | RedFg | BlueBg
+	assert.Equal(t, ItalicFm|BlueBg, c.Without(BoldFm|BlackFg))
+	assert.Equal(t, BoldFm|ItalicFm|RedFg, c.Without(BlackBg))
+	assert.Equal(t, BoldFm|ItalicFm, c.Without(GreenFg|GreenBg))
+	assert.Equal(t, c, c.Without(0))
+	assert.Equal(t, Color(0).Index(100),
+		Color(0).Index(100).BgIndex(200).Bold().Without(BoldFm|BlackBg))
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "bold", Format(BoldFm).String())
+	assert.Equal(t, "doubly-underline", Format(DoublyUnderlineFm).String())
+	assert.Equal(t, "BoldFm|ItalicFm", Format(BoldFm|ItalicFm).String())
+	assert.Equal(t, BoldFm, Format(BoldFm).Color())
+}
+
+func TestColor_Formats(t *testing.T) {
+	assert.Nil(t, RedFg.Formats())
+	assert.Equal(t, []Format{Format(BoldFm), Format(UnderlineFm),
+		Format(OverlinedFm)}, (OverlinedFm | RedFg | UnderlineFm |
+		BoldFm).Formats())
+	assert.Len(t, maskFm.Formats(), 25)
+}
+
+func TestColor_Foreground(t *testing.T) {
+	var n, ok = Color(0).Foreground()
+	assert.False(t, ok)
+	n, ok = (BoldFm | BlackFg).Foreground()
+	assert.True(t, ok)
+	assert.Equal(t, ColorIndex(0), n)
+	n, ok = (BrightFg | RedFg | GreenBg).Foreground()
+	assert.True(t, ok)
+	assert.Equal(t, ColorIndex(9), n)
+	n, ok = Color(0).Index(100).Foreground()
+	assert.True(t, ok)
+	assert.Equal(t, ColorIndex(100), n)
+	_, ok = GreenBg.Foreground()
+	assert.False(t, ok)
+}
+
+func TestColor_Background(t *testing.T) {
+	var n, ok = Color(0).Background()
+	assert.False(t, ok)
+	n, ok = BlackBg.Background()
+	assert.True(t, ok)
+	assert.Equal(t, ColorIndex(0), n)
+	n, ok = (BrightBg | RedBg | GreenFg).Background()
+	assert.True(t, ok)
+	assert.Equal(t, ColorIndex(9), n)
+	n, ok = Color(0).BgGray(5).Background()
+	assert.True(t, ok)
+	assert.Equal(t, ColorIndex(237), n)
+	_, ok = GreenFg.Background()
+	assert.False(t, ok)
+}
+
+func TestColor_ForegroundGray(t *testing.T) {
+	var n, ok = Color(0).Gray(7).BgGray(3).ForegroundGray()
+	assert.True(t, ok)
+	assert.Equal(t, GrayIndex(7), n)
+	_, ok = Color(0).Index(231).ForegroundGray()
+	assert.False(t, ok)
+	_, ok = Color(0).BgGray(3).ForegroundGray()
+	assert.False(t, ok)
+}
+
+func TestColor_BackgroundGray(t *testing.T) {
+	var n, ok = Color(0).Gray(7).BgGray(23).BackgroundGray()
+	assert.True(t, ok)
+	assert.Equal(t, GrayIndex(23), n)
+	_, ok = Color(0).BgIndex(231).BackgroundGray()
+	assert.False(t, ok)
+	_, ok = Color(0).Gray(3).BackgroundGray()
+	assert.False(t, ok)
+}
+
+func TestColor_IsBright(t *testing.T) {
+	assert.False(t, Color(0).IsBright())
+	assert.False(t, RedFg.IsBright())
+	assert.True(t, (BrightFg | RedFg).IsBright())
+	assert.True(t, Color(0).BrightWhite().IsBright())
+	assert.False(t, Color(0).Index(16).IsBright())
+	assert.False(t, (BrightBg | RedBg).IsBright())
+}
+
+func TestColor_IsBgBright(t *testing.T) {
+	assert.False(t, Color(0).IsBgBright())
+	assert.False(t, RedBg.IsBgBright())
+	assert.True(t, (BrightBg | RedBg).IsBgBright())
+	assert.False(t, Color(0).BgIndex(16).IsBgBright())
+	assert.False(t, (BrightFg | RedFg).IsBgBright())
+}
+
+func TestColor_Has(t *testing.T) {
+	var c = BoldFm | ItalicFm | RedFg | BlueBg
+	assert.True(t, c.Has(0))
+	assert.True(t, c.Has(BoldFm))
+	assert.True(t, c.Has(BoldFm|ItalicFm))
+	assert.True(t, c.Has(RedFg))
+	assert.True(t, c.Has(BlueBg|BoldFm))
+	assert.True(t, c.Has(c))
+	assert.False(t, c.Has(UnderlineFm))
+	assert.False(t, c.Has(BoldFm|UnderlineFm))
+	assert.False(t, c.Has(BlueFg))
+	assert.False(t, c.Has(RedFg|BrightFg))
+	assert.False(t, c.Has(RedBg))
+	assert.False(t, BoldFm.Has(RedFg))
+	assert.False(t, RedFg.Has(BlackFg))
+}
+
 func TestColor_Bold(t *testing.T) {
 	assert.True(t, Color(0).Bold()&BoldFm != 0, "not a bold")
 	assert.True(t, Color(FaintFm).Bold()&FaintFm == 0, "contains faint")
@@ -0,0 +1,192 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"io"
+)
+
+// compile-time check
+var _ Colorizer = (*Aurora)(nil)
+
+// A Colorizer represents all coloring and printing methods of the Aurora.
+// Libraries can accept a Colorizer instead of the *Aurora, leaving choice of
+// implementation to a user. For example, a Recorder can be used in tests,
+// and the Noop where colors are not required.
+//
+// The Colorizer grows with new methods of the Aurora, thus it can't be
+// implemented outside of the package, and new methods are not breaking
+// changes. Only the *Aurora and the *Recorder implement it. To wrap a
+// Colorizer, embed it into a structure, overriding required methods.
+type Colorizer interface {
+	Config() Config // configurations of the colorizer
+
+	// reset colors, formats and links
+	Reset(arg interface{}) Value
+	Clear(arg interface{}) Value
+
+	// formats
+	Bold(arg interface{}) Value
+	Faint(arg interface{}) Value
+	DoublyUnderline(arg interface{}) Value
+	Fraktur(arg interface{}) Value
+	Italic(arg interface{}) Value
+	Underline(arg interface{}) Value
+	SlowBlink(arg interface{}) Value
+	RapidBlink(arg interface{}) Value
+	Blink(arg interface{}) Value
+	Reverse(arg interface{}) Value
+	Inverse(arg interface{}) Value
+	Conceal(arg interface{}) Value
+	Hidden(arg interface{}) Value
+	CrossedOut(arg interface{}) Value
+	StrikeThrough(arg interface{}) Value
+	Framed(arg interface{}) Value
+	Encircled(arg interface{}) Value
+	Overlined(arg interface{}) Value
+	CurlyUnderline(arg interface{}) Value
+	DottedUnderline(arg interface{}) Value
+	DashedUnderline(arg interface{}) Value
+	ProportionalSpacing(arg interface{}) Value
+	IdeogramUnderline(arg interface{}) Value
+	IdeogramDoubleUnderline(arg interface{}) Value
+	IdeogramOverline(arg interface{}) Value
+	IdeogramDoubleOverline(arg interface{}) Value
+	IdeogramStress(arg interface{}) Value
+	Superscript(arg interface{}) Value
+	Subscript(arg interface{}) Value
+	Font(n FontIndex, arg interface{}) Value
+
+	// foreground colors
+	Black(arg interface{}) Value
+	Red(arg interface{}) Value
+	Green(arg interface{}) Value
+	Yellow(arg interface{}) Value
+	Blue(arg interface{}) Value
+	Magenta(arg interface{}) Value
+	Cyan(arg interface{}) Value
+	White(arg interface{}) Value
+	BrightBlack(arg interface{}) Value
+	BrightRed(arg interface{}) Value
+	BrightGreen(arg interface{}) Value
+	BrightYellow(arg interface{}) Value
+	BrightBlue(arg interface{}) Value
+	BrightMagenta(arg interface{}) Value
+	BrightCyan(arg interface{}) Value
+	BrightWhite(arg interface{}) Value
+	Index(n ColorIndex, arg interface{}) Value
+	Gray(n GrayIndex, arg interface{}) Value
+	TrueColor(rgb RGB, arg interface{}) Value
+
+	// background colors
+	BgBlack(arg interface{}) Value
+	BgRed(arg interface{}) Value
+	BgGreen(arg interface{}) Value
+	BgYellow(arg interface{}) Value
+	BgBlue(arg interface{}) Value
+	BgMagenta(arg interface{}) Value
+	BgCyan(arg interface{}) Value
+	BgWhite(arg interface{}) Value
+	BgBrightBlack(arg interface{}) Value
+	BgBrightRed(arg interface{}) Value
+	BgBrightGreen(arg interface{}) Value
+	BgBrightYellow(arg interface{}) Value
+	BgBrightBlue(arg interface{}) Value
+	BgBrightMagenta(arg interface{}) Value
+	BgBrightCyan(arg interface{}) Value
+	BgBrightWhite(arg interface{}) Value
+	BgIndex(n ColorIndex, arg interface{}) Value
+	BgGray(n GrayIndex, arg interface{}) Value
+	BgTrueColor(rgb RGB, arg interface{}) Value
+
+	// underline color
+	UnderlineIndex(n ColorIndex, arg interface{}) Value
+	UnderlineGray(n GrayIndex, arg interface{}) Value
+
+	// removal
+	NoBold(arg interface{}) Value
+	NoFaint(arg interface{}) Value
+	NoItalic(arg interface{}) Value
+	NoUnderline(arg interface{}) Value
+	NoBlink(arg interface{}) Value
+	NoReverse(arg interface{}) Value
+	NoConceal(arg interface{}) Value
+	NoCrossedOut(arg interface{}) Value
+	NoFraktur(arg interface{}) Value
+	NoFramed(arg interface{}) Value
+	NoEncircled(arg interface{}) Value
+	NoOverlined(arg interface{}) Value
+	NoForeground(arg interface{}) Value
+	NoBackground(arg interface{}) Value
+	NoUnderlineColor(arg interface{}) Value
+	NoProportionalSpacing(arg interface{}) Value
+	NoIdeograms(arg interface{}) Value
+	NoScript(arg interface{}) Value
+	NoFont(arg interface{}) Value
+	Without(arg interface{}, color Color) Value
+
+	// special methods
+	Colorize(arg interface{}, color Color) Value
+	Role(role string, arg interface{}) Value
+
+	// hyperlinks
+	Hyperlink(arg interface{}, target string, params ...HyperlinkParam) Value
+	HyperlinkTarget(arg interface{}) (target string)
+	HyperlinkParams(arg interface{}) (params []HyperlinkParam)
+
+	// printing
+	Sprintf(format interface{}, args ...interface{}) string
+	Sprint(args ...interface{}) string
+	Sprintln(args ...interface{}) string
+	Fprintf(w io.Writer, format interface{}, args ...interface{}) (n int, err error)
+	Fprint(w io.Writer, args ...interface{}) (n int, err error)
+	Fprintln(w io.Writer, args ...interface{}) (n int, err error)
+	Printf(format interface{}, args ...interface{}) (n int, err error)
+	Print(args ...interface{}) (n int, err error)
+	Println(args ...interface{}) (n int, err error)
+
+	// prevents implementations outside of the package
+	isColorizer()
+}
+
+func (a *Aurora) isColorizer()   {}
+func (r *Recorder) isColorizer() {}
+
+// Noop returns new colorizer that doesn't colorize anything and doesn't
+// add hyperlinks.
+func Noop() *Aurora {
+	return New(WithColors(false), WithHyperlinks(false))
+}
@@ -43,12 +43,18 @@ type Config struct {
 	Colors bool `json:"colors" yaml:"colors" toml:"colors" mapstructure:"colors"`
 	// Hyperlinks feature. Enable hyperlinks if true.
 	Hyperlinks bool `json:"hyperlinks" yaml:"hyperlinks" toml:"hyperlinks" mapstructure:"hyperlinks"`
+	// Profile of colors. Colors not supported by the profile are replaced
+	// with nearest supported ones.
+	Profile Profile `json:"profile" yaml:"profile" toml:"profile" mapstructure:"profile"`
+	// Theme maps semantic roles to styles.
+	Theme Theme `json:"theme" yaml:"theme" toml:"theme" mapstructure:"theme"`
 }
 
 // NewConfig returns new default Config.
 func NewConfig() (conf Config) {
 	conf.Colors = true
 	conf.Hyperlinks = true
+	conf.Theme = DefaultTheme()
 	return
 }
 
@@ -75,6 +81,10 @@ func (c *Config) AddFlags(fset *flag.FlagSet, prefix string) {
 		prefix+"hyperlinks",
 		c.Hyperlinks,
 		"enable hyperlinks")
+	fset.TextVar(&c.Profile,
+		prefix+"profile",
+		c.Profile,
+		"color profile: ansi16, ansi256 or truecolor")
 }
 
 // Apply given options for the Config.
@@ -89,6 +99,8 @@ func (c *Config) Options() (opts []Option) {
 	return []Option{
 		WithColors(c.Colors),
 		WithHyperlinks(c.Hyperlinks),
+		WithProfile(c.Profile),
+		WithTheme(c.Theme),
 	}
 }
 
@@ -99,6 +111,7 @@ func (c *Config) colorConfig() (cc colorConfig) {
 	if c.Hyperlinks {
 		cc |= hyperlinksPin
 	}
+	cc |= (colorConfig(c.Profile) << shiftProfile) & maskProfile
 	return
 }
 
@@ -118,3 +131,17 @@ func WithHyperlinks(t bool) Option {
 		c.Hyperlinks = t
 	}
 }
+
+// WithProfile is an Option that used to set color profile.
+func WithProfile(p Profile) Option {
+	return func(c *Config) {
+		c.Profile = p
+	}
+}
+
+// WithTheme is an Option that used to set a Theme.
+func WithTheme(t Theme) Option {
+	return func(c *Config) {
+		c.Theme = t
+	}
+}
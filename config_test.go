@@ -36,6 +36,7 @@
 package aurora
 
 import (
+	"encoding/json"
 	"flag"
 	"testing"
 
@@ -47,6 +48,7 @@ func TestNewConfig(t *testing.T) {
 	assert.Equal(t, Config{
 		Colors:     true,
 		Hyperlinks: true,
+		Theme:      DefaultTheme(),
 	}, NewConfig())
 }
 
@@ -100,6 +102,7 @@ func TestConfig_Apply(t *testing.T) {
 	assert.Equal(t, Config{
 		Colors:     false,
 		Hyperlinks: false,
+		Theme:      DefaultTheme(),
 	}, conf)
 }
 
@@ -112,6 +115,7 @@ func TestConfig_Options(t *testing.T) {
 	assert.Equal(t, Config{
 		Colors:     true,
 		Hyperlinks: true,
+		Theme:      DefaultTheme(),
 	}, c2)
 }
 
@@ -155,3 +159,38 @@ func TestWithHyperlinks(t *testing.T) {
 		Hyperlinks: false,
 	}, conf)
 }
+
+func TestWithTheme(t *testing.T) {
+	var (
+		conf  Config
+		theme = DefaultTheme()
+	)
+	theme.Error = NewStyle().Magenta()
+	conf.Apply(WithTheme(theme))
+	assert.Equal(t, Config{Theme: theme}, conf)
+}
+
+func TestConfig_json(t *testing.T) {
+	var conf = NewConfig()
+	var err = json.Unmarshal([]byte(`{
+		"colors": true,
+		"hyperlinks": false,
+		"theme": {
+			"error": "bold magenta on white",
+			"link": "underline blue link=https://example.com/{}"
+		}
+	}`), &conf)
+	require.NoError(t, err)
+	var want = DefaultTheme()
+	want.Error = NewStyle().Bold().Magenta().BgWhite()
+	want.Link = NewStyle().Underline().Blue().
+		Hyperlink("https://example.com/{}")
+	assert.Equal(t, Config{
+		Colors:     true,
+		Hyperlinks: false,
+		Theme:      want,
+	}, conf)
+	// invalid style
+	err = json.Unmarshal([]byte(`{"theme":{"error":"bold pinkish"}}`), &conf)
+	assert.Error(t, err)
+}
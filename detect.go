@@ -0,0 +1,108 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// IsTerminal returns true if given io.Writer is a terminal. Only an
+// *os.File can be a terminal.
+func IsTerminal(w io.Writer) bool {
+	var f, ok = w.(*os.File)
+	if !ok || f == nil {
+		return false
+	}
+	var fi, err = f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// is given environment variable set to something that means true
+func envTrue(key string) bool {
+	switch strings.ToLower(os.Getenv(key)) {
+	case "", "0", "false", "no", "off":
+		return false
+	}
+	return true
+}
+
+// DetectConfig returns configurations for given io.Writer detected by
+// environment variables and checking is the io.Writer a terminal. Colors
+// and hyperlinks are enabled for terminals only, and
+//
+//   - NO_COLOR (if it's not empty) and CLICOLOR=0 disable colors and
+//     hyperlinks;
+//   - FORCE_COLOR and CLICOLOR_FORCE (if it's not empty, 0 or false)
+//     enable them, even if the io.Writer is not a terminal;
+//   - TERM=dumb disables them for a terminal.
+//
+// The color profile is detected by COLORTERM and TERM variables.
+func DetectConfig(w io.Writer) (conf Config) {
+	conf = NewConfig()
+	var (
+		term   = os.Getenv("TERM")
+		colors = IsTerminal(w) && term != "dumb"
+	)
+	switch {
+	case os.Getenv("NO_COLOR") != "", os.Getenv("CLICOLOR") == "0":
+		colors = false
+	case envTrue("FORCE_COLOR"), envTrue("CLICOLOR_FORCE"):
+		colors = true
+	}
+	conf.Colors, conf.Hyperlinks = colors, colors
+	conf.Profile = detectProfile(term, os.Getenv("COLORTERM"))
+	return
+}
+
+func detectProfile(term, colorTerm string) Profile {
+	switch strings.ToLower(colorTerm) {
+	case "truecolor", "24bit":
+		return ProfileTrueColor
+	}
+	switch {
+	case strings.Contains(term, "256color"):
+		return ProfileANSI256
+	case term == "linux", term == "ansi", term == "cons25",
+		strings.HasPrefix(term, "vt"):
+		return ProfileANSI16
+	}
+	return ProfileANSI256
+}
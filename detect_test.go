@@ -0,0 +1,124 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTerminal(t *testing.T) {
+	assert.False(t, IsTerminal(new(bytes.Buffer)))
+	assert.False(t, IsTerminal((*os.File)(nil)))
+	var f, err = os.Create(filepath.Join(t.TempDir(), "file"))
+	require.NoError(t, err)
+	defer f.Close()
+	assert.False(t, IsTerminal(f))
+	if dev, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0); err == nil {
+		defer dev.Close()
+		// the null device is a character device, but not a terminal, it's
+		// fine to treat it as a terminal, nobody reads it
+		t.Log("null device:", IsTerminal(dev))
+	}
+}
+
+func setColorEnv(t *testing.T, kv ...string) {
+	t.Helper()
+	for _, key := range []string{
+		"NO_COLOR", "CLICOLOR", "CLICOLOR_FORCE", "FORCE_COLOR", "TERM",
+		"COLORTERM",
+	} {
+		t.Setenv(key, "")
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		t.Setenv(kv[i], kv[i+1])
+	}
+}
+
+func TestDetectConfig(t *testing.T) {
+	var buf bytes.Buffer
+
+	setColorEnv(t)
+	var conf = DetectConfig(&buf)
+	assert.False(t, conf.Colors)
+	assert.False(t, conf.Hyperlinks)
+	assert.Equal(t, ProfileANSI256, conf.Profile)
+	assert.Equal(t, DefaultTheme(), conf.Theme)
+
+	for _, env := range [][]string{
+		{"FORCE_COLOR", "1"},
+		{"FORCE_COLOR", "true"},
+		{"CLICOLOR_FORCE", "1"},
+	} {
+		setColorEnv(t, env...)
+		conf = DetectConfig(&buf)
+		assert.True(t, conf.Colors, env)
+		assert.True(t, conf.Hyperlinks, env)
+	}
+
+	for _, env := range [][]string{
+		{"FORCE_COLOR", "0"},
+		{"FORCE_COLOR", "false"},
+		{"CLICOLOR_FORCE", "0"},
+		{"FORCE_COLOR", "1", "NO_COLOR", "1"},
+		{"FORCE_COLOR", "1", "CLICOLOR", "0"},
+	} {
+		setColorEnv(t, env...)
+		conf = DetectConfig(&buf)
+		assert.False(t, conf.Colors, env)
+		assert.False(t, conf.Hyperlinks, env)
+	}
+
+	for _, tt := range []struct {
+		env  []string
+		want Profile
+	}{
+		{[]string{"COLORTERM", "truecolor"}, ProfileTrueColor},
+		{[]string{"COLORTERM", "24bit", "TERM", "xterm"}, ProfileTrueColor},
+		{[]string{"TERM", "xterm-256color"}, ProfileANSI256},
+		{[]string{"TERM", "xterm"}, ProfileANSI256},
+		{[]string{"TERM", "linux"}, ProfileANSI16},
+		{[]string{"TERM", "vt100"}, ProfileANSI16},
+	} {
+		setColorEnv(t, tt.env...)
+		assert.Equal(t, tt.want, DetectConfig(&buf).Profile, tt.env)
+	}
+}
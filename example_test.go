@@ -37,6 +37,7 @@ package aurora
 
 import (
 	"fmt"
+	"os"
 )
 
 func ExampleRed() {
@@ -80,7 +81,7 @@ func ExampleSprintf() {
 		),
 	)
 
-	// Output: [34mwe've got [0;36m5[0;34m cats, but want [0;1;35m25[0;34m[0m
+	// Output: [34mwe've got [36m5[34m cats, but want [1;35m25[0;34m[0m
 }
 
 func ExampleHyperlink() {
@@ -88,3 +89,28 @@ func ExampleHyperlink() {
 
 	// Output: ]8;;http://example.com/\[31mExample[0m]8;;\
 }
+
+func ExampleFprintf() {
+	Fprintf(os.Stdout,
+		Blue("we've got %d cats"), // <- blue format
+		Cyan(5),
+	)
+
+	// Output: [34mwe've got [36m5[34m cats[0m
+}
+
+func ExamplePrintln() {
+	Println(Red("red"), Bold("bold"))
+
+	// Output: [31mred[0m [1mbold[0m
+}
+
+func ExampleStyle() {
+	var (
+		errStyle    = NewStyle().Red().Bold()
+		detailStyle = errStyle.Inherit().Faint()
+	)
+	fmt.Println(errStyle.Apply("error:"), detailStyle.Apply("details"))
+
+	// Output: [1;31merror:[0m [2;31mdetails[0m
+}
@@ -137,8 +137,10 @@ func (h *hyperlink) headLen() int {
 }
 
 func (h *hyperlink) headBytes() (t []byte) {
-	t = make([]byte, 0, h.headLen())
+	return h.appendHead(make([]byte, 0, h.headLen()))
+}
 
+func (h *hyperlink) appendHead(t []byte) []byte {
 	t = append(t, linkStartEsc...)
 	for i, param := range h.params {
 		if i > 0 {
@@ -151,11 +153,7 @@ func (h *hyperlink) headBytes() (t []byte) {
 	t = append(t, ';')
 	t = append(t, h.target...)
 	t = append(t, linkMiddleEsc...)
-	return
-}
-
-func (h *hyperlink) tailLen() int {
-	return len(linkEndEsc)
+	return t
 }
 
 func (h *hyperlink) tailBytes() []byte {
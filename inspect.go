@@ -0,0 +1,108 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Inspect returns given string with escape sequences rendered in human
+// readable form, for debugging and test failures. For example
+//
+//	[bold red]error[/] [link=https://example.com]docs[/link]
+//
+// SGR sequences are rendered as resulting colors and formats, using the
+// same names as the Style, or "[/]" if they are reset. 24-bit colors are
+// rendered as nearest 8-bit ones, see the ApplySGR. Hyperlinks are
+// rendered as "[link=target]" and "[/link]", with parameters if any.
+// Sequences that don't change current state are omitted. Other escape
+// sequences are rendered quoted, like "\x1b[2K".
+func Inspect(s string) string {
+	var (
+		b     strings.Builder
+		color Color
+		link  bool
+	)
+	for i := 0; i < len(s); {
+		if s[i] != '\033' {
+			var j = strings.IndexByte(s[i:], '\033')
+			if j < 0 {
+				j = len(s) - i
+			}
+			b.WriteString(s[i : i+j])
+			i += j
+			continue
+		}
+		var seq = s[i : i+escapeLen(s[i:])]
+		i += len(seq)
+		if params, ok := sgrParams(seq); ok {
+			var next = color.ApplySGR(params)
+			if next == color {
+				continue
+			}
+			if color = next; color == 0 {
+				b.WriteString("[/]")
+				continue
+			}
+			b.WriteByte('[')
+			b.Write(appendColorString(nil, color, nil))
+			b.WriteByte(']')
+			continue
+		}
+		if target, params, ok := oscLink(seq); ok {
+			if target == "" {
+				if link {
+					b.WriteString("[/link]")
+				}
+				link = false
+				continue
+			}
+			link = true
+			b.WriteString("[link=")
+			b.WriteString(target)
+			for _, p := range params {
+				b.WriteByte(' ')
+				b.WriteString(p.String())
+			}
+			b.WriteByte(']')
+			continue
+		}
+		var quoted = strconv.QuoteToASCII(seq)
+		b.WriteString(quoted[1 : len(quoted)-1])
+	}
+	return b.String()
+}
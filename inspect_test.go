@@ -0,0 +1,73 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspect(t *testing.T) {
+	var au = New()
+	for s, want := range map[string]string{
+		"":                               "",
+		"plain":                          "plain",
+		"\033[0;1;31merror\033[0m":       "[bold red]error[/]",
+		"\033[73;12mx\033[75mx\033[10mx": "[superscript font(2)]x[font(2)]x[/]x",
+		au.Sprintf(au.Blue("we've got %d cats"), au.Cyan(5)): "" +
+			"[blue]we've got [cyan]5[blue] cats[/]",
+		au.Hyperlink(au.Red("docs"), "http://x/").String(): "" +
+			"[link=http://x/][red]docs[/][/link]",
+		au.Hyperlink("docs", "http://x/", HyperlinkID("1")).String(): "" +
+			"[link=http://x/ id=1]docs[/link]",
+		"\033[38;5;100;48;5;250m x \033[m": "[color(100) on gray(18)] x [/]",
+		"\033[1m\033[1ma\033[22m\033[0mb":  "[bold]a[/]b",
+		"\033[2Kline\033]0;title\a":        `\x1b[2Kline\x1b]0;title\a`,
+		"\033]8;;\033\\x":                  "x",
+	} {
+		assert.Equal(t, want, Inspect(s), "%q", s)
+	}
+}
+
+func ExampleInspect() {
+	var au = New()
+	fmt.Println(Inspect(au.Bold(au.Red("error")).String() + " " +
+		au.Hyperlink("docs", "https://example.com").String()))
+
+	// Output: [bold red]error[/] [link=https://example.com]docs[/link]
+}
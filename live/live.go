@@ -0,0 +1,305 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+// Package live implements a region of a terminal redrawn in place, like
+// docker pull status or a build matrix, while log lines scroll above it.
+//
+//	var area = live.New(os.Stderr, 0)
+//	defer area.Close()
+//	log.SetOutput(area) // log lines scroll above the area
+//
+//	area.Update(
+//		aurora.Bold("build matrix"),
+//		aurora.Green("linux   ok"),
+//		aurora.Yellow("darwin  running"),
+//	)
+//
+// Redraws are wrapped in synchronized output mode (DEC 2026) to avoid
+// flicker, and only changed lines are rewritten.
+package live
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+// Escape sequences used to redraw an Area.
+const (
+	syncBegin = "\033[?2026h" // begin synchronized update
+	syncEnd   = "\033[?2026l" // end synchronized update
+	eraseLine = "\033[2K"     // erase entire line
+	eraseDown = "\033[J"      // erase from cursor to end of screen
+)
+
+// An Area of a terminal redrawn in place. It's an io.Writer that writes
+// log lines above the Area. Methods of the Area are safe for concurrent
+// use. Fields of the Area should be changed before first use.
+type Area struct {
+	// Colorizer used to render lines. By default, it's configured for the
+	// writer of the Area. See aurora.DetectConfig.
+	Colorizer aurora.Colorizer
+	// Interactive is true if the Area redrawn in place. Otherwise, the
+	// last frame is written by the Close. By default, it's true if the
+	// writer is a terminal.
+	Interactive bool
+	// Sync wraps redraws in synchronized output mode. It's true by
+	// default. Terminals without the mode ignore it.
+	Sync bool
+	// Width of the terminal. Lines are truncated to the width to avoid
+	// wrapping, that breaks redrawing. Zero means no truncation.
+	Width int
+
+	w      io.Writer
+	height int // max number of lines, 0 means no limit
+
+	mu      sync.Mutex
+	lines   []string // current frame
+	drawn   []string // lines on the screen
+	partial []byte   // incomplete log line
+	closed  bool
+}
+
+// New Area writing to given writer and keeping given number of last
+// lines of a frame. Zero height means no limit.
+func New(w io.Writer, height int) *Area {
+	var conf = aurora.DetectConfig(w)
+	return &Area{
+		Colorizer:   aurora.New(conf.Options()...),
+		Interactive: aurora.IsTerminal(w),
+		Sync:        true,
+		w:           w,
+		height:      height,
+	}
+}
+
+// render screen lines of a frame
+func (a *Area) render(args []interface{}) (lines []string) {
+	lines = make([]string, 0, len(args))
+	for _, arg := range args {
+		var line = a.Colorizer.Sprint(arg)
+		if !a.Colorizer.Config().Colors {
+			line = aurora.Strip(line)
+		}
+		for _, part := range a.split(line) {
+			if a.Width > 0 {
+				part = aurora.Truncate(part, a.Width, "")
+			}
+			lines = append(lines, part)
+		}
+	}
+	if a.height > 0 && len(lines) > a.height {
+		lines = lines[len(lines)-a.height:] // keep last lines
+	}
+	return
+}
+
+// split given line by line breaks; every part keeps its own colors and
+// hyperlinks, that are closed at the end of the part
+func (a *Area) split(line string) (parts []string) {
+	if !strings.Contains(line, "\n") {
+		return []string{line}
+	}
+	var part []aurora.Value
+	for _, span := range aurora.Spans(line) {
+		for i, text := range strings.Split(span.Text, "\n") {
+			if i > 0 {
+				parts = append(parts, aurora.Join(part...))
+				part = part[:0]
+			}
+			var val = a.Colorizer.Colorize(strings.TrimSuffix(text, "\r"),
+				span.Color)
+			if span.Link != "" {
+				val = a.Colorizer.Hyperlink(val, span.Link, span.Params...)
+			}
+			part = append(part, val) // empty values are skipped by Join
+		}
+	}
+	return append(parts, aurora.Join(part...))
+}
+
+// Update the Area with given frame. Every argument is a line, that can be
+// an aurora.Value. An argument containing line breaks takes several lines.
+// Only changed lines are redrawn.
+func (a *Area) Update(lines ...interface{}) (err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return
+	}
+	a.lines = a.render(lines)
+	if !a.Interactive {
+		return // written by the Close
+	}
+	var buf bytes.Buffer
+	a.diff(&buf)
+	if buf.Len() == 0 {
+		return // nothing changed
+	}
+	return a.write(buf.Bytes())
+}
+
+// write given redraw sequence
+func (a *Area) write(redraw []byte) (err error) {
+	if a.Sync {
+		var buf = make([]byte, 0, len(syncBegin)+len(redraw)+len(syncEnd))
+		buf = append(buf, syncBegin...)
+		buf = append(buf, redraw...)
+		buf = append(buf, syncEnd...)
+		redraw = buf
+	}
+	_, err = a.w.Write(redraw)
+	return
+}
+
+// up moves cursor to the first line of the drawn Area
+func (a *Area) up(buf *bytes.Buffer) {
+	if len(a.drawn) > 0 {
+		fmt.Fprintf(buf, "\033[%dA", len(a.drawn))
+	}
+}
+
+// diff writes sequence redrawing changed lines; the cursor is at the
+// beginning of the line below the Area before and after
+func (a *Area) diff(buf *bytes.Buffer) {
+	var (
+		changed bool
+		skip    int // unchanged lines to skip
+	)
+	for i, line := range a.lines {
+		if i < len(a.drawn) && a.drawn[i] == line {
+			skip++
+			continue
+		}
+		if !changed {
+			a.up(buf)
+			changed = true
+		}
+		if skip > 0 {
+			fmt.Fprintf(buf, "\033[%dB", skip) // cursor down
+			skip = 0
+		}
+		buf.WriteString(eraseLine)
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	if len(a.lines) < len(a.drawn) {
+		if !changed {
+			a.up(buf)
+			changed = true
+		}
+		if skip > 0 {
+			fmt.Fprintf(buf, "\033[%dB", skip)
+		}
+		buf.WriteString(eraseDown)
+	} else if changed && skip > 0 {
+		fmt.Fprintf(buf, "\033[%dB", skip) // below the Area
+	}
+	if changed {
+		a.drawn = append(a.drawn[:0], a.lines...)
+	}
+}
+
+// Write log lines above the Area. An incomplete line is buffered until
+// next line break or the Close.
+func (a *Area) Write(p []byte) (n int, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed || !a.Interactive {
+		return a.w.Write(p)
+	}
+	var i = bytes.LastIndexByte(p, '\n')
+	if i < 0 {
+		a.partial = append(a.partial, p...)
+		return len(p), nil
+	}
+	var buf bytes.Buffer
+	a.up(&buf)
+	buf.WriteString(eraseDown)
+	buf.Write(a.partial)
+	buf.Write(p[:i+1])
+	a.partial = append(a.partial[:0], p[i+1:]...)
+	a.drawn = a.drawn[:0]
+	a.diff(&buf) // redraw entire Area
+	if err = a.write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Clear the Area removing it from the screen.
+func (a *Area) Clear() (err error) {
+	return a.Update()
+}
+
+// Close the Area leaving its last frame on the screen. Subsequent writes
+// are passed to the underlying writer as is, and updates are ignored.
+func (a *Area) Close() (err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return
+	}
+	a.closed = true
+	var buf bytes.Buffer
+	if len(a.partial) > 0 {
+		// incomplete log line
+		if a.Interactive {
+			a.up(&buf)
+			buf.WriteString(eraseDown)
+			a.drawn = a.drawn[:0]
+		}
+		buf.Write(a.partial)
+		buf.WriteByte('\n')
+		a.partial = nil
+	}
+	if a.Interactive {
+		a.diff(&buf)
+		if buf.Len() == 0 {
+			return
+		}
+		return a.write(buf.Bytes())
+	}
+	for _, line := range a.lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	_, err = a.w.Write(buf.Bytes())
+	return
+}
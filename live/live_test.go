@@ -0,0 +1,189 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package live
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/logrusorgru/aurora/v4/vt"
+)
+
+// colors and hyperlinks are enabled regardless of output
+var au = aurora.New()
+
+func testArea(height int) (a *Area, buf *bytes.Buffer) {
+	buf = new(bytes.Buffer)
+	a = New(buf, height)
+	a.Colorizer = au
+	a.Interactive = true
+	a.Sync = false
+	return
+}
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	var a = New(&buf, 2)
+	assert.False(t, a.Interactive)
+	assert.True(t, a.Sync)
+	assert.False(t, a.Colorizer.Config().Colors)
+	assert.Equal(t, 2, a.height)
+}
+
+func TestArea_Update(t *testing.T) {
+	var a, buf = testArea(0)
+	require.NoError(t, a.Update(au.Red("a"), "b", "c"))
+	assert.Equal(t, ""+
+		"\033[2K\033[31ma\033[0m\n"+
+		"\033[2Kb\n"+
+		"\033[2Kc\n", buf.String())
+	// the same frame
+	buf.Reset()
+	require.NoError(t, a.Update(au.Red("a"), "b", "c"))
+	assert.Equal(t, "", buf.String())
+	// one line changed
+	buf.Reset()
+	require.NoError(t, a.Update(au.Red("a"), "B", "c"))
+	assert.Equal(t, "\033[3A\033[1B\033[2KB\n\033[1B", buf.String())
+	// last line changed, and a new line
+	buf.Reset()
+	require.NoError(t, a.Update(au.Red("a"), "B", "C", "d"))
+	assert.Equal(t, "\033[3A\033[2B\033[2KC\n\033[2Kd\n", buf.String())
+	// shorter frame
+	buf.Reset()
+	require.NoError(t, a.Update(au.Red("a"), "B"))
+	assert.Equal(t, "\033[4A\033[2B\033[J", buf.String())
+	// clear
+	buf.Reset()
+	require.NoError(t, a.Clear())
+	assert.Equal(t, "\033[2A\033[J", buf.String())
+}
+
+func TestArea_Update_height(t *testing.T) {
+	var a, buf = testArea(2)
+	require.NoError(t, a.Update("a", "b", "c"))
+	assert.Equal(t, "\033[2Kb\n\033[2Kc\n", buf.String())
+}
+
+func TestArea_Update_width(t *testing.T) {
+	var a, buf = testArea(0)
+	a.Width = 3
+	require.NoError(t, a.Update(au.Red("hello")))
+	assert.Equal(t, "\033[2K\033[31mhel\033[0m\n", buf.String())
+}
+
+func TestArea_Update_multiline(t *testing.T) {
+	var a, buf = testArea(0)
+	require.NoError(t, a.Update(au.Red("a\nb"), "c"))
+	assert.Equal(t, ""+
+		"\033[2K\033[31ma\033[0m\n"+
+		"\033[2K\033[31mb\033[0m\n"+
+		"\033[2Kc\n", buf.String())
+	// styles of adjacent spans are switched by transitions
+	a, buf = testArea(0)
+	require.NoError(t, a.Update(au.Red("a").String()+au.Bold("b").String()+
+		"\nc"))
+	assert.Equal(t, ""+
+		"\033[2K\033[31ma\033[0;1mb\033[0m\n"+
+		"\033[2Kc\n", buf.String())
+	// on a screen
+	var s = vt.New(10, 6)
+	a = New(s, 3)
+	a.Colorizer, a.Interactive = au, true
+	fmt.Fprintln(s, "top")
+	require.NoError(t, a.Update("x\ny", au.Bold("z\nw")))
+	assert.Equal(t, "top\ny\nz\nw", s.String())
+	assert.Equal(t, aurora.BoldFm, s.Cell(3, 0).Color)
+	fmt.Fprintln(a, "log")
+	require.NoError(t, a.Update("1\n2", "3"))
+	assert.Equal(t, "top\nlog\n1\n2\n3", s.String())
+	require.NoError(t, a.Update("4"))
+	assert.Equal(t, "top\nlog\n4", s.String())
+}
+
+func TestArea_Update_sync(t *testing.T) {
+	var a, buf = testArea(0)
+	a.Sync = true
+	require.NoError(t, a.Update("a"))
+	assert.Equal(t, "\033[?2026h\033[2Ka\n\033[?2026l", buf.String())
+}
+
+func TestArea_Update_noColors(t *testing.T) {
+	var a, buf = testArea(0)
+	a.Colorizer = aurora.New(aurora.WithColors(false))
+	require.NoError(t, a.Update(au.Red("a"), "\033[1mb\033[0m"))
+	assert.Equal(t, "\033[2Ka\n\033[2Kb\n", buf.String())
+}
+
+func TestArea_Write(t *testing.T) {
+	var a, buf = testArea(0)
+	require.NoError(t, a.Update("a", "b"))
+	buf.Reset()
+	var n, err = fmt.Fprint(a, "log")
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "", buf.String()) // buffered
+	n, err = fmt.Fprint(a, " line\nnext ")
+	require.NoError(t, err)
+	assert.Equal(t, 11, n)
+	assert.Equal(t, "\033[2A\033[Jlog line\n\033[2Ka\n\033[2Kb\n",
+		buf.String())
+	buf.Reset()
+	require.NoError(t, a.Close())
+	assert.Equal(t, "\033[2A\033[Jnext \n\033[2Ka\n\033[2Kb\n", buf.String())
+	// closed
+	buf.Reset()
+	require.NoError(t, a.Update("c"))
+	require.NoError(t, a.Close())
+	fmt.Fprint(a, "x")
+	assert.Equal(t, "x", buf.String())
+}
+
+func TestArea_nonInteractive(t *testing.T) {
+	var a, buf = testArea(0)
+	a.Interactive = false
+	require.NoError(t, a.Update("a", "b"))
+	fmt.Fprint(a, "log\n")
+	require.NoError(t, a.Update("c", "d"))
+	assert.Equal(t, "log\n", buf.String())
+	require.NoError(t, a.Close())
+	assert.Equal(t, "log\nc\nd\n", buf.String())
+}
@@ -0,0 +1,471 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// names of formats in order of appearance in a string representation
+var formatNames = [...]struct {
+	name string
+	fm   Color
+}{
+	{"bold", BoldFm},
+	{"faint", FaintFm},
+	{"italic", ItalicFm},
+	{"underline", UnderlineFm},
+	{"slow-blink", SlowBlinkFm},
+	{"rapid-blink", RapidBlinkFm},
+	{"reverse", ReverseFm},
+	{"conceal", ConcealFm},
+	{"crossed-out", CrossedOutFm},
+	{"fraktur", FrakturFm},
+	{"doubly-underline", DoublyUnderlineFm},
+	{"framed", FramedFm},
+	{"encircled", EncircledFm},
+	{"overlined", OverlinedFm},
+	{"curly-underline", CurlyUnderlineFm},
+	{"dotted-underline", DottedUnderlineFm},
+	{"dashed-underline", DashedUnderlineFm},
+	{"proportional-spacing", ProportionalSpacingFm},
+	{"ideogram-underline", IdeogramUnderlineFm},
+	{"ideogram-double-underline", IdeogramDoubleUnderlineFm},
+	{"ideogram-overline", IdeogramOverlineFm},
+	{"ideogram-double-overline", IdeogramDoubleOverlineFm},
+	{"ideogram-stress", IdeogramStressFm},
+	{"superscript", SuperscriptFm},
+	{"subscript", SubscriptFm},
+}
+
+// aliases of formats, accepted by parser only
+var formatAliases = map[string]Color{
+	"blink":          BlinkFm,
+	"inverse":        InverseFm,
+	"hidden":         HiddenFm,
+	"strike-through": StrikeThroughFm,
+}
+
+// names of 8 standard colors
+var colorNames = [...]string{
+	"black",
+	"red",
+	"green",
+	"yellow",
+	"blue",
+	"magenta",
+	"cyan",
+	"white",
+}
+
+const brightPrefix = "bright-"
+
+// name of 8-bit color: standard and bright colors by names, grayscale
+// as gray(n), and other as color(n)
+func appendColorName(bs []byte, n uint8) []byte {
+	switch {
+	case n <= 7:
+		return append(bs, colorNames[n]...)
+	case n <= 15:
+		bs = append(bs, brightPrefix...)
+		return append(bs, colorNames[n-8]...)
+	case n >= 232:
+		bs = append(bs, "gray("...)
+		bs = strconv.AppendUint(bs, uint64(n-232), 10)
+		return append(bs, ')')
+	}
+	bs = append(bs, "color("...)
+	bs = strconv.AppendUint(bs, uint64(n), 10)
+	return append(bs, ')')
+}
+
+// appendColorString appends human readable representation of the Color,
+// like "bold underline red on bright-blue under yellow", where the "under"
+// is underline color; active 24-bit colors, if any, are represented in hex
+func appendColorString(bs []byte, c Color, t *trueColors) []byte {
+	var start = len(bs)
+	var sep = func() {
+		if len(bs) > start {
+			bs = append(bs, ' ')
+		}
+	}
+	for _, fn := range formatNames {
+		if c&fn.fm != 0 {
+			sep()
+			bs = append(bs, fn.name...)
+		}
+	}
+	if c&maskFont != 0 {
+		sep()
+		bs = append(bs, "font("...)
+		bs = strconv.AppendUint(bs, uint64((c&maskFont)>>shiftFont), 10)
+		bs = append(bs, ')')
+	}
+	var fg, bg, ul = t.active(c)
+	if c&flagFg != 0 {
+		sep()
+		if fg {
+			bs = t.fg.appendHex(bs)
+		} else {
+			bs = appendColorName(bs, uint8((c&maskFg)>>shiftFg))
+		}
+	}
+	if c&flagBg != 0 {
+		sep()
+		bs = append(bs, "on "...)
+		if bg {
+			bs = t.bg.appendHex(bs)
+		} else {
+			bs = appendColorName(bs, uint8((c&maskBg)>>shiftBg))
+		}
+	}
+	if c&flagUl != 0 {
+		sep()
+		bs = append(bs, "under "...)
+		if ul {
+			bs = t.ul.appendHex(bs)
+		} else {
+			bs = appendColorName(bs, uint8((c&maskUl)>>shiftUl))
+		}
+	}
+	return bs
+}
+
+// parse number of color(n) or gray(n) like token
+func parseColorFunc(token, name string, max uint64) (n uint8, ok bool,
+	err error) {
+
+	if !strings.HasPrefix(token, name+"(") {
+		return // not this function
+	}
+	if !strings.HasSuffix(token, ")") {
+		return 0, true, fmt.Errorf("missing closing parenthesis in %q", token)
+	}
+	var num = token[len(name)+1 : len(token)-1]
+	var u uint64
+	if u, err = strconv.ParseUint(strings.TrimSpace(num), 10, 8); err != nil ||
+		u > max {
+		return 0, true, fmt.Errorf("invalid %s number %q, want 0-%d", name,
+			num, max)
+	}
+	return uint8(u), true, nil
+}
+
+// parse name of 8-bit color; for 24-bit colors it returns nearest 8-bit
+// color and the 24-bit color itself
+func parseColorName(token string) (n uint8, rgb RGB, isRGB bool, err error) {
+	var name = strings.TrimPrefix(token, brightPrefix)
+	for i, cn := range colorNames {
+		if cn != name {
+			continue
+		}
+		if len(name) != len(token) {
+			return uint8(i) + 8, 0, false, nil // bright
+		}
+		return uint8(i), 0, false, nil
+	}
+	var ok bool
+	if n, ok, err = parseColorFunc(token, "color", 255); ok {
+		return
+	}
+	if n, ok, err = parseColorFunc(token, "gray", 23); ok {
+		return n + 232, 0, false, err
+	}
+	if token != "" && token[0] >= '0' && token[0] <= '9' {
+		var u uint64
+		if u, err = strconv.ParseUint(token, 10, 8); err != nil {
+			return 0, 0, false, fmt.Errorf("invalid color number %q, "+
+				"want 0-255", token)
+		}
+		return uint8(u), 0, false, nil
+	}
+	if rgb, ok, err = parseTrueColor(token); ok {
+		if err != nil {
+			return
+		}
+		return uint8(rgb.Index()), rgb, true, nil
+	}
+	return 0, 0, false, fmt.Errorf("unknown color or format %q", token)
+}
+
+// splitStyle splits given string by spaces, except spaces inside
+// parentheses, like in "rgb(255, 136, 0)"
+func splitStyle(s string) (tokens []string) {
+	var (
+		depth int
+		start = -1
+	)
+	for i, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case unicode.IsSpace(r) && depth == 0:
+			if start >= 0 {
+				tokens = append(tokens, s[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, s[start:])
+	}
+	return
+}
+
+// ParseColor parses human readable representation of colors and formats,
+// like "bold italic #ff8800 on blue". The string consists of
+//
+//   - formats, like "bold", "underline" or "curly-underline", see the
+//     Format type for all names;
+//   - foreground color;
+//   - background color after the "on";
+//   - underline color after the "under";
+//   - alternative font, like "font(3)".
+//
+// A color is one of
+//
+//   - standard color name, like "red";
+//   - bright color name, like "bright-red" or "bright red";
+//   - 8-bit color index, like "color(208)" or "208";
+//   - gray color from 0 to 23, like "gray(5)";
+//   - 24-bit color, like "#ff8800", "rgb(255, 136, 0)", "hsl(32, 100%, 50%)"
+//     or CSS color name, like "orange", see the ParseRGB.
+//
+// The standard color names are terminal colors, not the CSS ones. 24-bit
+// colors are replaced with nearest 8-bit colors, excluding standard and
+// bright ones. The string is case-insensitive. Returned error describes bad
+// token, if any. Text representation of a Style uses the same syntax,
+// keeping 24-bit foreground, background and underline colors.
+func ParseColor(s string) (Color, error) {
+	return parseColor(s)
+}
+
+// parseColor parses string like "bold underline red on bright-blue", where
+// color after the "on" is background and color after the "under" is
+// underline color
+func parseColor(s string) (c Color, err error) {
+	c, _, err = parseColors(s)
+	return
+}
+
+// parseColors is like the parseColor, but it also returns 24-bit
+// foreground, background and underline colors, if any
+func parseColors(s string) (c Color, t *trueColors, err error) {
+	var (
+		tokens = splitStyle(strings.ToLower(s))
+		prep   string // "on" or "under" before next color
+		bright bool   // "bright" before next color
+		hasFg  bool
+		hasBg  bool
+		hasUl  bool
+		n      uint8
+		rgb    RGB
+		isRGB  bool
+	)
+	for _, token := range tokens {
+		if token == "on" || token == "under" {
+			if prep != "" || bright {
+				return 0, nil, fmt.Errorf("unexpected %q after %q", token,
+					lastOf(prep, bright))
+			}
+			prep = token
+			continue
+		}
+		if token == "bright" {
+			if bright {
+				return 0, nil, fmt.Errorf("unexpected %q after \"bright\"",
+					token)
+			}
+			bright = true
+			continue
+		}
+		if prep == "" && !bright {
+			if fm, ok := formatByName(token); ok {
+				c |= fm
+				continue
+			}
+			if n, ok, err := parseColorFunc(token, "font", 9); ok {
+				if err != nil {
+					return 0, nil, err
+				}
+				c = c.Font(FontIndex(n))
+				continue
+			}
+		}
+		if bright {
+			if n, err = parseBrightName(token); err != nil {
+				return 0, nil, err
+			}
+		} else if n, rgb, isRGB, err = parseColorName(token); err != nil {
+			return 0, nil, err
+		}
+		switch {
+		case prep == "on" && hasBg:
+			return 0, nil, fmt.Errorf("second background color %q", token)
+		case prep == "on":
+			c, hasBg = c.BgIndex(ColorIndex(n)), true
+			if isRGB {
+				t = t.withBg(rgb)
+			}
+		case prep == "under" && hasUl:
+			return 0, nil, fmt.Errorf("second underline color %q", token)
+		case prep == "under":
+			c, hasUl = c.UnderlineIndex(ColorIndex(n)), true
+			if isRGB {
+				t = t.withUl(rgb)
+			}
+		case hasFg:
+			return 0, nil, fmt.Errorf("second foreground color %q", token)
+		default:
+			c, hasFg = c.Index(ColorIndex(n)), true
+			if isRGB {
+				t = t.withFg(rgb)
+			}
+		}
+		prep, bright, isRGB = "", false, false
+	}
+	switch {
+	case bright:
+		return 0, nil, fmt.Errorf("missing color name after \"bright\"")
+	case prep == "on":
+		return 0, nil, fmt.Errorf("missing background color after \"on\"")
+	case prep == "under":
+		return 0, nil, fmt.Errorf("missing underline color after \"under\"")
+	}
+	return
+}
+
+// name of last keyword for error messages
+func lastOf(prep string, bright bool) string {
+	if bright {
+		return "bright"
+	}
+	return prep
+}
+
+// parse standard color name after the "bright" word
+func parseBrightName(token string) (n uint8, err error) {
+	for i, cn := range colorNames {
+		if cn == token {
+			return uint8(i) + 8, nil
+		}
+	}
+	return 0, fmt.Errorf("\"bright\" followed by %q, want one of standard "+
+		"color names: %s", token, strings.Join(colorNames[:], ", "))
+}
+
+func formatByName(name string) (fm Color, ok bool) {
+	for _, fn := range formatNames {
+		if fn.name == name {
+			return fn.fm, true
+		}
+	}
+	fm, ok = formatAliases[name]
+	return
+}
+
+// MarshalText implements encoding.TextMarshaler interface. It returns
+// human readable representation of the Color, like
+//
+//	bold underline red on bright-blue under gray(5)
+//
+// or empty text for zero Color. The representation is lossless and can be
+// parsed back by the UnmarshalText or by the ParseColor. It returns error
+// for a Color that has bits not produced by the Color methods.
+func (c Color) MarshalText() (text []byte, err error) {
+	text = appendColorString(nil, c, nil)
+	if back, err := parseColor(string(text)); err != nil || back != c {
+		return nil, fmt.Errorf("invalid color %s", c.String())
+	}
+	return
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface. See the
+// ParseColor for syntax.
+func (c *Color) UnmarshalText(text []byte) (err error) {
+	var color Color
+	if color, err = parseColor(string(text)); err != nil {
+		return
+	}
+	*c = color
+	return
+}
+
+// MarshalJSON implements json.Marshaler interface. The Color is
+// represented as JSON string, see MarshalText.
+func (c Color) MarshalJSON() ([]byte, error) {
+	var text, err = c.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. It accepts JSON
+// string, see UnmarshalText, null that keeps the Color unchanged, or
+// JSON number that is raw Color value, as it was encoded before the
+// Color implemented json.Marshaler.
+func (c *Color) UnmarshalJSON(data []byte) (err error) {
+	if string(data) == "null" {
+		return
+	}
+	if len(data) > 0 && data[0] >= '0' && data[0] <= '9' {
+		var color Color
+		if err = json.Unmarshal(data, (*uint64)(&color)); err != nil {
+			return fmt.Errorf("color number: %w", err)
+		}
+		if _, err = color.MarshalText(); err != nil {
+			return
+		}
+		*c = color
+		return
+	}
+	var text string
+	if err = json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("color must be a JSON string or number: %w", err)
+	}
+	return c.UnmarshalText([]byte(text))
+}
@@ -0,0 +1,276 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_appendColorString(t *testing.T) {
+	for _, tt := range []struct {
+		color Color
+		want  string
+	}{
+		{0, ""},
+		{BoldFm | UnderlineFm, "bold underline"},
+		{RedFg, "red"},
+		{BrightFg | BlueFg, "bright-blue"},
+		{BlueBg, "on blue"},
+		{BoldFm | RedFg | BrightBg | BlueBg, "bold red on bright-blue"},
+		{Color(0).Index(100).BgGray(5), "color(100) on gray(5)"},
+		{CurlyUnderlineFm | RedFg | Color(0).UnderlineIndex(11),
+			"curly-underline red under bright-yellow"},
+		{Color(0).UnderlineGray(3) | BlueBg, "on blue under gray(3)"},
+		{SuperscriptFm.Font(2).Red(), "superscript font(2) red"},
+	} {
+		assert.Equal(t, tt.want, string(appendColorString(nil, tt.color, nil)))
+	}
+}
+
+func Test_splitStyle(t *testing.T) {
+	assert.Nil(t, splitStyle(""))
+	assert.Nil(t, splitStyle(" \t "))
+	assert.Equal(t, []string{"bold", "rgb(1, 2, 3)", "on", "red"},
+		splitStyle("  bold rgb(1, 2, 3)\ton  red "))
+	assert.Equal(t, []string{"rgb(1, 2 red"}, splitStyle("rgb(1, 2 red"))
+	assert.Equal(t, []string{"a)", "b"}, splitStyle("a) b"))
+}
+
+func TestParseColor(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want Color
+	}{
+		{"", 0},
+		{"bold italic #ff8800 on blue", BoldFm | ItalicFm |
+			Color(0).Index(208) | BlueBg},
+		{"Bright Red on BRIGHT blue", BrightFg | RedFg | BrightBg | BlueBg},
+		{"bright-red on bright-blue", BrightFg | RedFg | BrightBg | BlueBg},
+		{"color(123) on gray(5)", Color(0).Index(123).BgGray(5)},
+		{"123 on 0", Color(0).Index(123) | BlackBg},
+		{"#f80", Color(0).Index(208)},
+		{"#FF0000", Color(0).Index(196)},
+		{"#000000", Color(0).Index(16)},
+		{"rgb(255, 136, 0)", Color(0).Index(208)},
+		{"rgb(0,0,255) on rgb( 255 , 255 , 255 )",
+			Color(0).Index(21).BgIndex(231)},
+		{"curly-underline under #f00", CurlyUnderlineFm |
+			Color(0).UnderlineIndex(196)},
+		{"underline under bright yellow", UnderlineFm |
+			Color(0).UnderlineIndex(11)},
+		{"hsl(32, 100%, 50%) on orange", Color(0).Index(208).BgIndex(214)},
+		{"red on RebeccaPurple", RedFg | Color(0).BgIndex(60)},
+		{"under gray", Color(0).UnderlineIndex(244)},
+	} {
+		var got, err = ParseColor(tt.s)
+		require.NoError(t, err, tt.s)
+		assert.Equal(t, tt.want, got, tt.s)
+	}
+	for _, tt := range []struct {
+		s, err string
+	}{
+		{"pinkish", `unknown color or format "pinkish"`},
+		{"bold #ff88", `invalid hex color "#ff88", want #rgb or #rrggbb`},
+		{"#xyz", `invalid hex color "#xyz", want #rgb or #rrggbb`},
+		{"rgb(1, 2)", `invalid rgb color "rgb(1, 2)", want rgb(r, g, b)`},
+		{"rgb(1, 2, 300)", `invalid rgb component "300" in "rgb(1, 2, 300)", ` +
+			`want 0-255`},
+		{"rgb(1, 2, 3", `missing closing parenthesis in "rgb(1, 2, 3"`},
+		{"256", `invalid color number "256", want 0-255`},
+		{"bright", `missing color name after "bright"`},
+		{"bright gray(5)", `"bright" followed by "gray(5)", want one of ` +
+			`standard color names: black, red, green, yellow, blue, magenta, ` +
+			`cyan, white`},
+		{"bright on red", `unexpected "on" after "bright"`},
+		{"bright bright red", `unexpected "bright" after "bright"`},
+		{"red blue", `second foreground color "blue"`},
+		{"on red on", `missing background color after "on"`},
+	} {
+		var _, err = ParseColor(tt.s)
+		if assert.Error(t, err, tt.s) {
+			assert.Equal(t, tt.err, err.Error())
+		}
+	}
+}
+
+func Test_parseColor(t *testing.T) {
+	// round trip
+	var colors = []Color{0}
+	for _, fn := range formatNames {
+		colors = append(colors, fn.fm)
+	}
+	for i := 0; i < 256; i++ {
+		colors = append(colors,
+			Color(0).Index(ColorIndex(i)),
+			Color(0).BgIndex(ColorIndex(i)),
+			Color(0).Index(ColorIndex(i)).BgIndex(ColorIndex(255-i)).Bold(),
+			Color(0).UnderlineIndex(ColorIndex(i)).CurlyUnderline(),
+		)
+	}
+	for i := 1; i <= 9; i++ {
+		colors = append(colors, RedFg.Font(FontIndex(i)))
+	}
+	colors = append(colors, maskFm|RedFg|BlueBg|Color(0).UnderlineGray(7))
+	for _, c := range colors {
+		var s = string(appendColorString(nil, c, nil))
+		var got, err = parseColor(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, c, got, s)
+	}
+	// aliases
+	var got, err = parseColor("Blink Inverse  hidden strike-through")
+	require.NoError(t, err)
+	assert.Equal(t, BlinkFm|InverseFm|HiddenFm|StrikeThroughFm, got)
+	// errors
+	for _, s := range []string{
+		"pinkish",
+		"red blue",
+		"on red on blue",
+		"red on",
+		"on bold",
+		"color(256)",
+		"color(x)",
+		"color(1",
+		"gray(24)",
+		"under red under blue",
+		"on under red",
+		"red under",
+		"under red under",
+		"under bold",
+		"font(10)",
+	} {
+		_, err = parseColor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestColor_MarshalText(t *testing.T) {
+	var colors = []Color{0, maskFm, maskFm | RedFg | BlueBg}
+	for _, fn := range formatNames {
+		colors = append(colors, fn.fm, fn.fm|RedFg)
+	}
+	for i := 0; i < 256; i++ {
+		var n = ColorIndex(i)
+		colors = append(colors,
+			Color(0).Index(n),
+			Color(0).BgIndex(n),
+			Color(0).UnderlineIndex(n),
+			Color(0).Index(n).BgIndex(255-n).UnderlineIndex(n/2).Bold(),
+		)
+	}
+	for i := 0; i < 24; i++ {
+		var n = GrayIndex(i)
+		colors = append(colors, Color(0).Gray(n).BgGray(23-n).UnderlineGray(n))
+	}
+	for i := 1; i <= 9; i++ {
+		colors = append(colors, Color(0).Font(FontIndex(i)).Italic())
+	}
+	for _, c := range colors {
+		var text, err = c.MarshalText()
+		require.NoError(t, err, c.String())
+		var got Color
+		require.NoError(t, got.UnmarshalText(text), string(text))
+		assert.Equal(t, c, got, string(text))
+	}
+	var text, err = (BoldFm | RedFg | BrightBg | BlueBg).MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "bold red on bright-blue", string(text))
+	// invalid
+	for _, c := range []Color{
+		Color(1 << shiftFg),      // color without presence flag
+		1 << 56,                  // unused bit
+		Color(colorPin) | BoldFm, // reserved bit
+	} {
+		_, err = c.MarshalText()
+		assert.Error(t, err, c.String())
+	}
+	var c = RedFg
+	assert.Error(t, c.UnmarshalText([]byte("bold pinkish")))
+	assert.Equal(t, RedFg, c) // unchanged
+}
+
+func TestColor_json(t *testing.T) {
+	type config struct {
+		Error  Color   `json:"error"`
+		Muted  Color   `json:"muted"`
+		Plain  Color   `json:"plain"`
+		Colors []Color `json:"colors,omitempty"`
+	}
+	var conf = config{
+		Error:  BoldFm | RedFg,
+		Muted:  Color(0).Gray(12).Italic(),
+		Colors: []Color{CurlyUnderlineFm | Color(0).UnderlineIndex(9)},
+	}
+	var data, err = json.Marshal(conf)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"error": "bold red",
+		"muted": "italic gray(12)",
+		"plain": "",
+		"colors": ["curly-underline under bright-red"]
+	}`, string(data))
+	var got config
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, conf, got)
+	// map keys
+	data, err = json.Marshal(map[Color]int{BoldFm: 1})
+	require.NoError(t, err)
+	assert.Equal(t, `{"bold":1}`, string(data))
+	// null
+	got = config{Error: RedFg}
+	require.NoError(t, json.Unmarshal([]byte(`{"error":null}`), &got))
+	assert.Equal(t, RedFg, got.Error)
+	// raw number
+	got = config{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"error": `+strconv.FormatUint(uint64(BoldFm|RedFg), 10)+`,
+		"plain": 0
+	}`), &got))
+	assert.Equal(t, config{Error: BoldFm | RedFg}, got)
+	// errors
+	assert.Error(t, json.Unmarshal([]byte(`{"error":true}`), &got))
+	assert.Error(t, json.Unmarshal([]byte(`{"error":1.5}`), &got))
+	assert.Error(t, json.Unmarshal([]byte(`{"error":72057594037927936}`),
+		&got)) // 1 << 56
+	assert.Error(t, json.Unmarshal([]byte(`{"error":"pinkish"}`), &got))
+	_, err = json.Marshal(config{Error: 1 << 56})
+	assert.Error(t, err)
+}
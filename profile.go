@@ -0,0 +1,172 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"fmt"
+)
+
+// A Profile is a color profile of a terminal. It limits colors used for
+// output. Colors not supported by a profile are replaced with nearest
+// supported ones.
+type Profile uint8
+
+// Color profiles.
+const (
+	ProfileANSI256   Profile = iota // 256 colors, default
+	ProfileANSI16                   // 8 standard and 8 bright colors only, plain underline
+	ProfileTrueColor                // 24-bit colors
+)
+
+var profileNames = [...]string{
+	ProfileANSI256:   "ansi256",
+	ProfileANSI16:    "ansi16",
+	ProfileTrueColor: "truecolor",
+}
+
+// String returns name of the Profile.
+func (p Profile) String() string {
+	if int(p) < len(profileNames) {
+		return profileNames[p]
+	}
+	return fmt.Sprintf("Profile(%d)", uint8(p))
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (p Profile) MarshalText() (text []byte, err error) {
+	if int(p) >= len(profileNames) {
+		return nil, fmt.Errorf("unknown color profile %d", uint8(p))
+	}
+	return []byte(profileNames[p]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface. It accepts
+// "ansi16", "ansi256" and "truecolor".
+func (p *Profile) UnmarshalText(text []byte) error {
+	for i, name := range profileNames {
+		if name == string(text) {
+			*p = Profile(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown color profile %q", text)
+}
+
+// xterm levels of the 6x6x6 color cube
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// standard xterm values of 16 standard and bright colors
+var standardColors = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// indexRGB returns xterm RGB values of 8-bit color
+func indexRGB(n uint8) (r, g, b uint8) {
+	switch {
+	case n < 16:
+		var c = standardColors[n]
+		return c[0], c[1], c[2]
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[(n/6)%6], cubeLevels[n%6]
+	}
+	var v = 8 + 10*(n-232)
+	return v, v, v
+}
+
+// squared distance between two colors
+func distance(r1, g1, b1, r2, g2, b2 uint8) int {
+	var dr, dg, db = int(r1) - int(r2), int(g1) - int(g2), int(b1) - int(b2)
+	return dr*dr + dg*dg + db*db
+}
+
+// nearest color index in [0; limit) range for given RGB
+func nearestIndex(r, g, b uint8, limit int) (n uint8) {
+	var min = -1
+	for i := 0; i < limit; i++ {
+		var ir, ig, ib = indexRGB(uint8(i))
+		if d := distance(r, g, b, ir, ig, ib); min < 0 || d < min {
+			min, n = d, uint8(i)
+		}
+	}
+	return
+}
+
+// nearest color index in [16; 255] range for given RGB; standard and
+// bright colors are skipped, since they depend on a terminal theme
+func nearestExtended(r, g, b uint8) (n uint8) {
+	var min = -1
+	for i := 16; i < 256; i++ {
+		var ir, ig, ib = indexRGB(uint8(i))
+		if d := distance(r, g, b, ir, ig, ib); min < 0 || d < min {
+			min, n = d, uint8(i)
+		}
+	}
+	return
+}
+
+// nearest of 16 colors for every of 256 colors
+var ansi16 = func() (t [256]uint8) {
+	for i := range t {
+		if i < 16 {
+			t[i] = uint8(i)
+			continue
+		}
+		var r, g, b = indexRGB(uint8(i))
+		t[i] = nearestIndex(r, g, b, 16)
+	}
+	return
+}()
+
+// to16 replaces 8-bit foreground and background colors
+// with nearest standard or bright ones, and underline styles
+// with plain underline, dropping underline color
+func (c Color) to16() Color {
+	if c&maskUlStyle != 0 {
+		c = (c &^ maskUlStyle) | UnderlineFm
+	}
+	c &^= maskUl
+	if c&flagFg != 0 {
+		c = c.Index(ColorIndex(ansi16[uint8((c&maskFg)>>shiftFg)]))
+	}
+	if c&flagBg != 0 {
+		c = c.BgIndex(ColorIndex(ansi16[uint8((c&maskBg)>>shiftBg)]))
+	}
+	return c
+}
@@ -0,0 +1,106 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile_String(t *testing.T) {
+	assert.Equal(t, "ansi256", ProfileANSI256.String())
+	assert.Equal(t, "ansi16", ProfileANSI16.String())
+	assert.Equal(t, "truecolor", ProfileTrueColor.String())
+	assert.Equal(t, "Profile(10)", Profile(10).String())
+}
+
+func TestProfile_MarshalText(t *testing.T) {
+	for _, p := range []Profile{
+		ProfileANSI256, ProfileANSI16, ProfileTrueColor,
+	} {
+		var text, err = p.MarshalText()
+		require.NoError(t, err)
+		var got Profile
+		require.NoError(t, got.UnmarshalText(text))
+		assert.Equal(t, p, got)
+	}
+	var _, err = Profile(10).MarshalText()
+	assert.Error(t, err)
+	var p Profile
+	assert.Error(t, p.UnmarshalText([]byte("ansi")))
+}
+
+func Test_indexRGB(t *testing.T) {
+	var r, g, b = indexRGB(196)
+	assert.Equal(t, [3]uint8{255, 0, 0}, [3]uint8{r, g, b})
+	r, g, b = indexRGB(232)
+	assert.Equal(t, [3]uint8{8, 8, 8}, [3]uint8{r, g, b})
+	r, g, b = indexRGB(255)
+	assert.Equal(t, [3]uint8{238, 238, 238}, [3]uint8{r, g, b})
+	r, g, b = indexRGB(9)
+	assert.Equal(t, [3]uint8{255, 0, 0}, [3]uint8{r, g, b})
+}
+
+func TestColor_to16(t *testing.T) {
+	assert.Equal(t, BoldFm|RedFg|BlueBg, (BoldFm | RedFg | BlueBg).to16())
+	assert.Equal(t, BrightFg|RedFg, Color(0).Index(196).to16())
+	assert.Equal(t, BlackBg, Color(0).BgGray(0).to16())
+	assert.Equal(t, WhiteBg, Color(0).BgGray(23).to16())
+	for i := 0; i < 256; i++ {
+		var c = Color(0).Index(ColorIndex(i)).BgIndex(ColorIndex(i)).to16()
+		assert.True(t, (c&maskFg)>>shiftFg < 16)
+		assert.True(t, (c&maskBg)>>shiftBg < 16)
+	}
+	// underline styles and color
+	assert.Equal(t, UnderlineFm|RedFg,
+		(CurlyUnderlineFm | RedFg).UnderlineIndex(100).to16())
+	assert.Equal(t, UnderlineFm, DashedUnderlineFm.to16())
+	assert.Equal(t, BoldFm, Color(0).UnderlineGray(5).Bold().to16())
+}
+
+func TestAurora_profile(t *testing.T) {
+	var a = New(WithProfile(ProfileANSI16))
+	assert.Equal(t, "\033[1;91;40mx\033[0m",
+		a.Index(196, "x").BgGray(5).Bold().String())
+	assert.Equal(t, BrightFg|RedFg, a.Index(196, "x").Color())
+	assert.Equal(t, "\033[38;5;196mx\033[0m", New().Index(196, "x").String())
+	assert.Equal(t, "\033[4mx\033[0m",
+		a.CurlyUnderline("x").UnderlineIndex(1).String())
+	assert.Equal(t, "\033[4:3;58;5;1mx\033[0m",
+		New().CurlyUnderline("x").UnderlineIndex(1).String())
+}
@@ -0,0 +1,407 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+// Package progress implements progress bars and spinners.
+//
+//	var g = progress.New(os.Stderr)
+//	var bar = g.Bar("download", 100)
+//	var spin = g.Spinner("indexing")
+//	g.Start()
+//	defer g.Stop()
+//
+//	bar.Add(10)
+//	spin.Done()
+//
+// If the writer is a terminal, then widgets of a Group are redrawn in
+// place using carriage return and erase-line sequences. Otherwise, the
+// Group periodically writes plain log lines. Colors are rendered by the
+// colorizer of the Group, thus its color profile is respected.
+package progress
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+// Default intervals.
+const (
+	DefaultInterval    = 100 * time.Millisecond // redraw interval
+	DefaultLogInterval = 5 * time.Second        // log lines interval
+)
+
+// DefaultGradient is red to green gradient of 256-colors.
+var DefaultGradient = []aurora.Color{
+	aurora.Color(0).Index(196),
+	aurora.Color(0).Index(202),
+	aurora.Color(0).Index(208),
+	aurora.Color(0).Index(214),
+	aurora.Color(0).Index(220),
+	aurora.Color(0).Index(226),
+	aurora.Color(0).Index(190),
+	aurora.Color(0).Index(154),
+	aurora.Color(0).Index(118),
+	aurora.Color(0).Index(82),
+	aurora.Color(0).Index(46),
+}
+
+// A widget of a Group.
+type widget interface {
+	line(au aurora.Colorizer, now time.Time) string // line for a terminal
+	log(now time.Time) string                       // plain log line
+}
+
+// A Group of progress bars and spinners drawn to the same writer. Fields
+// of the Group should be changed before the Start.
+type Group struct {
+	// Colorizer used to render widgets. By default, it's configured for
+	// the writer of the Group. See aurora.DetectConfig.
+	Colorizer aurora.Colorizer
+	// Interactive is true if widgets are redrawn in place. Otherwise,
+	// plain log lines are written. By default, it's true if the writer
+	// is a terminal.
+	Interactive bool
+	// Interval of redrawing, for interactive Group. Zero or negative
+	// means the DefaultInterval.
+	Interval time.Duration
+	// LogInterval of log lines, for non-interactive Group. Zero or
+	// negative means the DefaultLogInterval.
+	LogInterval time.Duration
+
+	w   io.Writer
+	now func() time.Time // for tests
+
+	mu      sync.Mutex
+	widgets []widget
+	lines   int // number of lines drawn
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New Group drawing to given writer.
+func New(w io.Writer) *Group {
+	var conf = aurora.DetectConfig(w)
+	return &Group{
+		Colorizer:   aurora.New(conf.Options()...),
+		Interactive: aurora.IsTerminal(w),
+		Interval:    DefaultInterval,
+		LogInterval: DefaultLogInterval,
+		w:           w,
+		now:         time.Now,
+	}
+}
+
+func (g *Group) add(wg widget) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.widgets = append(g.widgets, wg)
+}
+
+// Bar adds a progress bar with given name and total.
+func (g *Group) Bar(name string, total int64) (b *Bar) {
+	b = &Bar{
+		Name:     name,
+		Width:    40,
+		Gradient: DefaultGradient,
+		Fill:     "█",
+		Empty:    "░",
+		total:    total,
+		start:    g.now(),
+	}
+	g.add(b)
+	return
+}
+
+// Spinner adds a spinner with given name.
+func (g *Group) Spinner(name string) (s *Spinner) {
+	s = &Spinner{
+		Name:      name,
+		Frames:    []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+		DoneFrame: "✓",
+		Style:     aurora.NewStyle().Cyan(),
+		start:     g.now(),
+	}
+	g.add(s)
+	return
+}
+
+// Draw widgets once. It's called periodically after the Start. For an
+// interactive Group, previously drawn lines are redrawn.
+func (g *Group) Draw() (err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.draw()
+}
+
+func (g *Group) draw() (err error) {
+	var (
+		buf bytes.Buffer
+		now = g.now()
+	)
+	if !g.Interactive {
+		for _, wg := range g.widgets {
+			buf.WriteString(wg.log(now))
+			buf.WriteByte('\n')
+		}
+		_, err = g.w.Write(buf.Bytes())
+		return
+	}
+	if g.lines > 0 {
+		buf.WriteByte('\r')
+		if g.lines > 1 {
+			fmt.Fprintf(&buf, "\033[%dA", g.lines-1) // cursor up
+		}
+	}
+	for i, wg := range g.widgets {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString("\033[2K") // erase line
+		buf.WriteString(wg.line(g.Colorizer, now))
+	}
+	g.lines = len(g.widgets)
+	_, err = g.w.Write(buf.Bytes())
+	return
+}
+
+// Start drawing widgets periodically in a goroutine.
+func (g *Group) Start() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stop != nil {
+		return // already started
+	}
+	g.stop, g.done = make(chan struct{}), make(chan struct{})
+	go g.run(g.interval(), g.stop, g.done)
+}
+
+// interval of drawing, positive
+func (g *Group) interval() time.Duration {
+	if g.Interactive {
+		if g.Interval > 0 {
+			return g.Interval
+		}
+		return DefaultInterval
+	}
+	if g.LogInterval > 0 {
+		return g.LogInterval
+	}
+	return DefaultLogInterval
+}
+
+func (g *Group) run(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+	var tick = time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			g.Draw()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Stop drawing and draw final state of widgets.
+func (g *Group) Stop() (err error) {
+	g.mu.Lock()
+	var stop, done = g.stop, g.done
+	g.stop, g.done = nil, nil
+	g.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err = g.draw(); err != nil {
+		return
+	}
+	if g.Interactive && g.lines > 0 {
+		_, err = g.w.Write([]byte{'\n'})
+		g.lines = 0
+	}
+	return
+}
+
+// A Bar is a progress bar. Methods of the Bar are safe for concurrent
+// use. Fields of the Bar should be changed before the Start of its Group.
+type Bar struct {
+	Name     string         // name of the bar
+	Width    int            // width of the bar, in cells
+	Gradient []aurora.Color // colors of filled cells, from start to end
+	Fill     string         // filled cell
+	Empty    string         // empty cell
+
+	total   int64
+	current atomic.Int64
+	start   time.Time
+}
+
+// Add given number to current value of the Bar.
+func (b *Bar) Add(n int64) {
+	b.current.Add(n)
+}
+
+// Increment current value of the Bar.
+func (b *Bar) Increment() {
+	b.current.Add(1)
+}
+
+// Set current value of the Bar.
+func (b *Bar) Set(n int64) {
+	b.current.Store(n)
+}
+
+// Done sets current value of the Bar to its total.
+func (b *Bar) Done() {
+	b.current.Store(b.total)
+}
+
+// Current value of the Bar.
+func (b *Bar) Current() int64 {
+	return b.current.Load()
+}
+
+// Total of the Bar.
+func (b *Bar) Total() int64 {
+	return b.total
+}
+
+// progress from 0 to 1
+func (b *Bar) progress() (current int64, p float64) {
+	current = b.current.Load()
+	if b.total <= 0 {
+		return current, 0
+	}
+	p = float64(current) / float64(b.total)
+	if p > 1 {
+		p = 1
+	} else if p < 0 {
+		p = 0
+	}
+	return
+}
+
+func (b *Bar) line(au aurora.Colorizer, now time.Time) string {
+	var (
+		current, p = b.progress()
+		filled     = int(p * float64(b.Width))
+		segs       []aurora.Value
+	)
+	if b.Name != "" {
+		segs = append(segs, au.Reset(b.Name+" "))
+	}
+	// group cells of the same color
+	for i := 0; i < filled; {
+		var color = b.color(i)
+		var j = i + 1
+		for j < filled && b.color(j) == color {
+			j++
+		}
+		segs = append(segs, au.Colorize(strings.Repeat(b.Fill, j-i), color))
+		i = j
+	}
+	if filled < b.Width {
+		segs = append(segs, au.BrightBlack(strings.Repeat(b.Empty,
+			b.Width-filled)))
+	}
+	segs = append(segs, au.Reset(fmt.Sprintf(" %3d%% %d/%d", int(p*100),
+		current, b.total)))
+	return aurora.Join(segs...) // minimal transitions between colors
+}
+
+// color of given cell of the bar
+func (b *Bar) color(cell int) aurora.Color {
+	if len(b.Gradient) == 0 {
+		return 0
+	}
+	return b.Gradient[cell*len(b.Gradient)/b.Width]
+}
+
+func (b *Bar) log(now time.Time) string {
+	var current, p = b.progress()
+	return fmt.Sprintf("%s: %d%% (%d/%d) %s", b.Name, int(p*100), current,
+		b.total, now.Sub(b.start).Truncate(time.Second))
+}
+
+// A Spinner shows activity of a process without known total. Methods of
+// the Spinner are safe for concurrent use. Fields of the Spinner should
+// be changed before the Start of its Group.
+type Spinner struct {
+	Name      string       // name of the spinner
+	Frames    []string     // frames of the spinner
+	DoneFrame string       // frame shown when the spinner is done
+	Style     aurora.Style // style of frames
+
+	frame atomic.Int64
+	done  atomic.Bool
+	start time.Time
+}
+
+// Done marks the Spinner as finished.
+func (s *Spinner) Done() {
+	s.done.Store(true)
+}
+
+func (s *Spinner) line(au aurora.Colorizer, now time.Time) string {
+	var frame string
+	if s.done.Load() {
+		frame = s.DoneFrame
+	} else if len(s.Frames) > 0 {
+		frame = s.Frames[int(s.frame.Add(1)-1)%len(s.Frames)]
+	}
+	return aurora.Join(
+		au.Colorize(frame, s.Style.Color()).Merge(s.Style),
+		au.Reset(" "+s.Name+" "),
+		au.BrightBlack(now.Sub(s.start).Truncate(time.Second)))
+}
+
+func (s *Spinner) log(now time.Time) string {
+	var state = "in progress"
+	if s.done.Load() {
+		state = "done"
+	}
+	return fmt.Sprintf("%s: %s %s", s.Name, state,
+		now.Sub(s.start).Truncate(time.Second))
+}
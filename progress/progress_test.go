@@ -0,0 +1,206 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+// test Group with fixed clock
+func testGroup(interactive bool, opts ...aurora.Option) (g *Group,
+	buf *bytes.Buffer, clock *time.Time) {
+
+	buf = new(bytes.Buffer)
+	g = New(buf)
+	g.Colorizer = aurora.New(opts...)
+	g.Interactive = interactive
+	clock = new(time.Time)
+	*clock = time.Date(2022, 10, 8, 0, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return *clock }
+	return
+}
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	var g = New(&buf)
+	assert.False(t, g.Interactive)
+	assert.False(t, g.Colorizer.Config().Colors)
+	assert.Equal(t, DefaultInterval, g.Interval)
+	assert.Equal(t, DefaultLogInterval, g.LogInterval)
+}
+
+func TestBar(t *testing.T) {
+	var g, _, _ = testGroup(false)
+	var b = g.Bar("x", 10)
+	assert.Equal(t, int64(10), b.Total())
+	b.Add(2)
+	b.Increment()
+	assert.Equal(t, int64(3), b.Current())
+	b.Set(5)
+	assert.Equal(t, int64(5), b.Current())
+	b.Done()
+	assert.Equal(t, int64(10), b.Current())
+}
+
+func TestGroup_Draw_plain(t *testing.T) {
+	var g, buf, clock = testGroup(true, aurora.WithColors(false))
+	var b = g.Bar("bar", 4)
+	b.Width = 4
+	b.Set(1)
+	var s = g.Spinner("spin")
+	s.Frames = []string{"-", "|"}
+	require.NoError(t, g.Draw())
+	assert.Equal(t, ""+
+		"\033[2Kbar █░░░  25% 1/4\n"+
+		"\033[2K- spin 0s", buf.String())
+	buf.Reset()
+	*clock = clock.Add(2 * time.Second)
+	b.Done()
+	require.NoError(t, g.Draw())
+	assert.Equal(t, "\r\033[1A"+
+		"\033[2Kbar ████ 100% 4/4\n"+
+		"\033[2K| spin 2s", buf.String())
+	buf.Reset()
+	s.Done()
+	require.NoError(t, g.Stop())
+	assert.Equal(t, "\r\033[1A"+
+		"\033[2Kbar ████ 100% 4/4\n"+
+		"\033[2K✓ spin 2s\n", buf.String())
+}
+
+func TestGroup_Draw_colors(t *testing.T) {
+	var g, buf, _ = testGroup(true)
+	var b = g.Bar("", 4)
+	b.Width = 4
+	b.Gradient = []aurora.Color{aurora.RedFg, aurora.GreenFg}
+	b.Set(3)
+	require.NoError(t, g.Draw())
+	assert.Equal(t, "\033[2K"+
+		"\033[31m██\033[32m█\033[90m░\033[0m  75% 3/4",
+		buf.String())
+}
+
+func TestGroup_Draw_profile(t *testing.T) {
+	var g, buf, _ = testGroup(true, aurora.WithProfile(aurora.ProfileANSI16))
+	var b = g.Bar("", 10)
+	b.Width = 10
+	b.Done()
+	require.NoError(t, g.Draw())
+	assert.NotContains(t, buf.String(), "38;5;")
+	assert.Contains(t, buf.String(), "\033[91m") // bright red
+	assert.Contains(t, buf.String(), "\033[92m") // bright green
+}
+
+func TestGroup_Draw_log(t *testing.T) {
+	var g, buf, clock = testGroup(false)
+	var b = g.Bar("bar", 200)
+	var s = g.Spinner("spin")
+	b.Set(50)
+	*clock = clock.Add(3*time.Second + time.Millisecond)
+	require.NoError(t, g.Draw())
+	s.Done()
+	require.NoError(t, g.Stop())
+	assert.Equal(t, ""+
+		"bar: 25% (50/200) 3s\n"+
+		"spin: in progress 3s\n"+
+		"bar: 25% (50/200) 3s\n"+
+		"spin: done 3s\n", buf.String())
+}
+
+// synchronized buffer
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+func TestGroup_Start(t *testing.T) {
+	var out syncBuffer
+	var g = New(&out)
+	g.LogInterval = time.Millisecond
+	var b = g.Bar("bar", 100)
+	g.Start()
+	g.Start() // no-op
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				b.Increment()
+				time.Sleep(time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, g.Stop())
+	var lines = strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.True(t, len(lines) > 1)
+	assert.True(t, strings.HasPrefix(lines[len(lines)-1],
+		"bar: 100% (100/100)"))
+}
+
+func TestGroup_interval(t *testing.T) {
+	var g = New(new(bytes.Buffer))
+	g.Interval, g.LogInterval = 0, -time.Second
+	assert.Equal(t, DefaultLogInterval, g.interval())
+	g.Interactive = true
+	assert.Equal(t, DefaultInterval, g.interval())
+	g.Interval = time.Second
+	assert.Equal(t, time.Second, g.interval())
+	g.Start() // must not panic
+	require.NoError(t, g.Stop())
+}
@@ -0,0 +1,684 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"io"
+	"sync"
+)
+
+// compile-time check
+var _ Colorizer = (*Recorder)(nil)
+
+// A Record is a single call of a Recorder method.
+type Record struct {
+	Method string        // name of the method, e.g. "Red" or "Sprintf"
+	Args   []interface{} // arguments of the method, except an io.Writer
+	Value  Value         // result of a coloring method, or zero Value
+}
+
+// Color returns colors and formats of resulting Value of the Record.
+func (r Record) Color() Color {
+	return r.Value.Color()
+}
+
+// A Recorder is a Colorizer that captures all calls. It's intended to
+// be used in tests to check requested styles. The Recorder delegates
+// all calls to underlying Colorizer. It's safe for concurrent use.
+type Recorder struct {
+	c       Colorizer
+	mu      sync.Mutex
+	records []Record
+}
+
+// NewRecorder returns new Recorder that uses given Colorizer. If the
+// Colorizer is nil, then new Aurora with colors and hyperlinks is used.
+func NewRecorder(c Colorizer) *Recorder {
+	if c == nil {
+		c = New(WithColors(true), WithHyperlinks(true))
+	}
+	return &Recorder{c: c}
+}
+
+func (r *Recorder) colorizer() Colorizer {
+	if r.c == nil {
+		return Noop() // zero Recorder
+	}
+	return r.c
+}
+
+func (r *Recorder) add(method string, val Value, args []interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, Record{
+		Method: method,
+		Args:   args,
+		Value:  val,
+	})
+}
+
+func (r *Recorder) record(method string, val Value,
+	args ...interface{}) Value {
+
+	r.add(method, val, args)
+	return val
+}
+
+// record printing method, copying arguments before they changed
+func (r *Recorder) recordPrint(method string, format interface{},
+	withFormat bool, args []interface{}) {
+
+	var ra = make([]interface{}, 0, len(args)+1)
+	if withFormat {
+		ra = append(ra, format)
+	}
+	ra = append(ra, args...)
+	r.add(method, Value{}, ra)
+}
+
+// Records returns copy of all recorded calls.
+func (r *Recorder) Records() (records []Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	records = make([]Record, len(r.records))
+	copy(records, r.records)
+	return
+}
+
+// DropRecords drops all recorded calls.
+func (r *Recorder) DropRecords() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = nil
+}
+
+// Config of underlying Colorizer.
+func (r *Recorder) Config() Config {
+	return r.colorizer().Config()
+}
+
+// Reset records the call.
+func (r *Recorder) Reset(arg interface{}) Value {
+	return r.record("Reset", r.colorizer().Reset(arg), arg)
+}
+
+// Clear records the call.
+func (r *Recorder) Clear(arg interface{}) Value {
+	return r.record("Clear", r.colorizer().Clear(arg), arg)
+}
+
+// Bold records the call.
+func (r *Recorder) Bold(arg interface{}) Value {
+	return r.record("Bold", r.colorizer().Bold(arg), arg)
+}
+
+// Faint records the call.
+func (r *Recorder) Faint(arg interface{}) Value {
+	return r.record("Faint", r.colorizer().Faint(arg), arg)
+}
+
+// DoublyUnderline records the call.
+func (r *Recorder) DoublyUnderline(arg interface{}) Value {
+	return r.record("DoublyUnderline", r.colorizer().DoublyUnderline(arg), arg)
+}
+
+// Fraktur records the call.
+func (r *Recorder) Fraktur(arg interface{}) Value {
+	return r.record("Fraktur", r.colorizer().Fraktur(arg), arg)
+}
+
+// Italic records the call.
+func (r *Recorder) Italic(arg interface{}) Value {
+	return r.record("Italic", r.colorizer().Italic(arg), arg)
+}
+
+// Underline records the call.
+func (r *Recorder) Underline(arg interface{}) Value {
+	return r.record("Underline", r.colorizer().Underline(arg), arg)
+}
+
+// SlowBlink records the call.
+func (r *Recorder) SlowBlink(arg interface{}) Value {
+	return r.record("SlowBlink", r.colorizer().SlowBlink(arg), arg)
+}
+
+// RapidBlink records the call.
+func (r *Recorder) RapidBlink(arg interface{}) Value {
+	return r.record("RapidBlink", r.colorizer().RapidBlink(arg), arg)
+}
+
+// Blink records the call.
+func (r *Recorder) Blink(arg interface{}) Value {
+	return r.record("Blink", r.colorizer().Blink(arg), arg)
+}
+
+// Reverse records the call.
+func (r *Recorder) Reverse(arg interface{}) Value {
+	return r.record("Reverse", r.colorizer().Reverse(arg), arg)
+}
+
+// Inverse records the call.
+func (r *Recorder) Inverse(arg interface{}) Value {
+	return r.record("Inverse", r.colorizer().Inverse(arg), arg)
+}
+
+// Conceal records the call.
+func (r *Recorder) Conceal(arg interface{}) Value {
+	return r.record("Conceal", r.colorizer().Conceal(arg), arg)
+}
+
+// Hidden records the call.
+func (r *Recorder) Hidden(arg interface{}) Value {
+	return r.record("Hidden", r.colorizer().Hidden(arg), arg)
+}
+
+// CrossedOut records the call.
+func (r *Recorder) CrossedOut(arg interface{}) Value {
+	return r.record("CrossedOut", r.colorizer().CrossedOut(arg), arg)
+}
+
+// StrikeThrough records the call.
+func (r *Recorder) StrikeThrough(arg interface{}) Value {
+	return r.record("StrikeThrough", r.colorizer().StrikeThrough(arg), arg)
+}
+
+// Framed records the call.
+func (r *Recorder) Framed(arg interface{}) Value {
+	return r.record("Framed", r.colorizer().Framed(arg), arg)
+}
+
+// Encircled records the call.
+func (r *Recorder) Encircled(arg interface{}) Value {
+	return r.record("Encircled", r.colorizer().Encircled(arg), arg)
+}
+
+// Overlined records the call.
+func (r *Recorder) Overlined(arg interface{}) Value {
+	return r.record("Overlined", r.colorizer().Overlined(arg), arg)
+}
+
+// CurlyUnderline records the call.
+func (r *Recorder) CurlyUnderline(arg interface{}) Value {
+	return r.record("CurlyUnderline", r.colorizer().CurlyUnderline(arg), arg)
+}
+
+// DottedUnderline records the call.
+func (r *Recorder) DottedUnderline(arg interface{}) Value {
+	return r.record("DottedUnderline", r.colorizer().DottedUnderline(arg), arg)
+}
+
+// DashedUnderline records the call.
+func (r *Recorder) DashedUnderline(arg interface{}) Value {
+	return r.record("DashedUnderline", r.colorizer().DashedUnderline(arg), arg)
+}
+
+// ProportionalSpacing records the call.
+func (r *Recorder) ProportionalSpacing(arg interface{}) Value {
+	return r.record("ProportionalSpacing", r.colorizer().ProportionalSpacing(arg), arg)
+}
+
+// IdeogramUnderline records the call.
+func (r *Recorder) IdeogramUnderline(arg interface{}) Value {
+	return r.record("IdeogramUnderline", r.colorizer().IdeogramUnderline(arg), arg)
+}
+
+// IdeogramDoubleUnderline records the call.
+func (r *Recorder) IdeogramDoubleUnderline(arg interface{}) Value {
+	return r.record("IdeogramDoubleUnderline", r.colorizer().IdeogramDoubleUnderline(arg), arg)
+}
+
+// IdeogramOverline records the call.
+func (r *Recorder) IdeogramOverline(arg interface{}) Value {
+	return r.record("IdeogramOverline", r.colorizer().IdeogramOverline(arg), arg)
+}
+
+// IdeogramDoubleOverline records the call.
+func (r *Recorder) IdeogramDoubleOverline(arg interface{}) Value {
+	return r.record("IdeogramDoubleOverline", r.colorizer().IdeogramDoubleOverline(arg), arg)
+}
+
+// IdeogramStress records the call.
+func (r *Recorder) IdeogramStress(arg interface{}) Value {
+	return r.record("IdeogramStress", r.colorizer().IdeogramStress(arg), arg)
+}
+
+// Superscript records the call.
+func (r *Recorder) Superscript(arg interface{}) Value {
+	return r.record("Superscript", r.colorizer().Superscript(arg), arg)
+}
+
+// Subscript records the call.
+func (r *Recorder) Subscript(arg interface{}) Value {
+	return r.record("Subscript", r.colorizer().Subscript(arg), arg)
+}
+
+// Font records the call.
+func (r *Recorder) Font(n FontIndex, arg interface{}) Value {
+	return r.record("Font", r.colorizer().Font(n, arg), n, arg)
+}
+
+// Black records the call.
+func (r *Recorder) Black(arg interface{}) Value {
+	return r.record("Black", r.colorizer().Black(arg), arg)
+}
+
+// Red records the call.
+func (r *Recorder) Red(arg interface{}) Value {
+	return r.record("Red", r.colorizer().Red(arg), arg)
+}
+
+// Green records the call.
+func (r *Recorder) Green(arg interface{}) Value {
+	return r.record("Green", r.colorizer().Green(arg), arg)
+}
+
+// Yellow records the call.
+func (r *Recorder) Yellow(arg interface{}) Value {
+	return r.record("Yellow", r.colorizer().Yellow(arg), arg)
+}
+
+// Blue records the call.
+func (r *Recorder) Blue(arg interface{}) Value {
+	return r.record("Blue", r.colorizer().Blue(arg), arg)
+}
+
+// Magenta records the call.
+func (r *Recorder) Magenta(arg interface{}) Value {
+	return r.record("Magenta", r.colorizer().Magenta(arg), arg)
+}
+
+// Cyan records the call.
+func (r *Recorder) Cyan(arg interface{}) Value {
+	return r.record("Cyan", r.colorizer().Cyan(arg), arg)
+}
+
+// White records the call.
+func (r *Recorder) White(arg interface{}) Value {
+	return r.record("White", r.colorizer().White(arg), arg)
+}
+
+// BrightBlack records the call.
+func (r *Recorder) BrightBlack(arg interface{}) Value {
+	return r.record("BrightBlack", r.colorizer().BrightBlack(arg), arg)
+}
+
+// BrightRed records the call.
+func (r *Recorder) BrightRed(arg interface{}) Value {
+	return r.record("BrightRed", r.colorizer().BrightRed(arg), arg)
+}
+
+// BrightGreen records the call.
+func (r *Recorder) BrightGreen(arg interface{}) Value {
+	return r.record("BrightGreen", r.colorizer().BrightGreen(arg), arg)
+}
+
+// BrightYellow records the call.
+func (r *Recorder) BrightYellow(arg interface{}) Value {
+	return r.record("BrightYellow", r.colorizer().BrightYellow(arg), arg)
+}
+
+// BrightBlue records the call.
+func (r *Recorder) BrightBlue(arg interface{}) Value {
+	return r.record("BrightBlue", r.colorizer().BrightBlue(arg), arg)
+}
+
+// BrightMagenta records the call.
+func (r *Recorder) BrightMagenta(arg interface{}) Value {
+	return r.record("BrightMagenta", r.colorizer().BrightMagenta(arg), arg)
+}
+
+// BrightCyan records the call.
+func (r *Recorder) BrightCyan(arg interface{}) Value {
+	return r.record("BrightCyan", r.colorizer().BrightCyan(arg), arg)
+}
+
+// BrightWhite records the call.
+func (r *Recorder) BrightWhite(arg interface{}) Value {
+	return r.record("BrightWhite", r.colorizer().BrightWhite(arg), arg)
+}
+
+// Index records the call.
+func (r *Recorder) Index(n ColorIndex, arg interface{}) Value {
+	return r.record("Index", r.colorizer().Index(n, arg), n, arg)
+}
+
+// TrueColor records the call.
+func (r *Recorder) TrueColor(rgb RGB, arg interface{}) Value {
+	return r.record("TrueColor", r.colorizer().TrueColor(rgb, arg), rgb, arg)
+}
+
+// Gray records the call.
+func (r *Recorder) Gray(n GrayIndex, arg interface{}) Value {
+	return r.record("Gray", r.colorizer().Gray(n, arg), n, arg)
+}
+
+// BgBlack records the call.
+func (r *Recorder) BgBlack(arg interface{}) Value {
+	return r.record("BgBlack", r.colorizer().BgBlack(arg), arg)
+}
+
+// BgRed records the call.
+func (r *Recorder) BgRed(arg interface{}) Value {
+	return r.record("BgRed", r.colorizer().BgRed(arg), arg)
+}
+
+// BgGreen records the call.
+func (r *Recorder) BgGreen(arg interface{}) Value {
+	return r.record("BgGreen", r.colorizer().BgGreen(arg), arg)
+}
+
+// BgYellow records the call.
+func (r *Recorder) BgYellow(arg interface{}) Value {
+	return r.record("BgYellow", r.colorizer().BgYellow(arg), arg)
+}
+
+// BgBlue records the call.
+func (r *Recorder) BgBlue(arg interface{}) Value {
+	return r.record("BgBlue", r.colorizer().BgBlue(arg), arg)
+}
+
+// BgMagenta records the call.
+func (r *Recorder) BgMagenta(arg interface{}) Value {
+	return r.record("BgMagenta", r.colorizer().BgMagenta(arg), arg)
+}
+
+// BgCyan records the call.
+func (r *Recorder) BgCyan(arg interface{}) Value {
+	return r.record("BgCyan", r.colorizer().BgCyan(arg), arg)
+}
+
+// BgWhite records the call.
+func (r *Recorder) BgWhite(arg interface{}) Value {
+	return r.record("BgWhite", r.colorizer().BgWhite(arg), arg)
+}
+
+// BgBrightBlack records the call.
+func (r *Recorder) BgBrightBlack(arg interface{}) Value {
+	return r.record("BgBrightBlack", r.colorizer().BgBrightBlack(arg), arg)
+}
+
+// BgBrightRed records the call.
+func (r *Recorder) BgBrightRed(arg interface{}) Value {
+	return r.record("BgBrightRed", r.colorizer().BgBrightRed(arg), arg)
+}
+
+// BgBrightGreen records the call.
+func (r *Recorder) BgBrightGreen(arg interface{}) Value {
+	return r.record("BgBrightGreen", r.colorizer().BgBrightGreen(arg), arg)
+}
+
+// BgBrightYellow records the call.
+func (r *Recorder) BgBrightYellow(arg interface{}) Value {
+	return r.record("BgBrightYellow", r.colorizer().BgBrightYellow(arg), arg)
+}
+
+// BgBrightBlue records the call.
+func (r *Recorder) BgBrightBlue(arg interface{}) Value {
+	return r.record("BgBrightBlue", r.colorizer().BgBrightBlue(arg), arg)
+}
+
+// BgBrightMagenta records the call.
+func (r *Recorder) BgBrightMagenta(arg interface{}) Value {
+	return r.record("BgBrightMagenta", r.colorizer().BgBrightMagenta(arg), arg)
+}
+
+// BgBrightCyan records the call.
+func (r *Recorder) BgBrightCyan(arg interface{}) Value {
+	return r.record("BgBrightCyan", r.colorizer().BgBrightCyan(arg), arg)
+}
+
+// BgBrightWhite records the call.
+func (r *Recorder) BgBrightWhite(arg interface{}) Value {
+	return r.record("BgBrightWhite", r.colorizer().BgBrightWhite(arg), arg)
+}
+
+// BgIndex records the call.
+func (r *Recorder) BgIndex(n ColorIndex, arg interface{}) Value {
+	return r.record("BgIndex", r.colorizer().BgIndex(n, arg), n, arg)
+}
+
+// BgGray records the call.
+func (r *Recorder) BgGray(n GrayIndex, arg interface{}) Value {
+	return r.record("BgGray", r.colorizer().BgGray(n, arg), n, arg)
+}
+
+// BgTrueColor records the call.
+func (r *Recorder) BgTrueColor(rgb RGB, arg interface{}) Value {
+	return r.record("BgTrueColor", r.colorizer().BgTrueColor(rgb, arg), rgb,
+		arg)
+}
+
+// UnderlineIndex records the call.
+func (r *Recorder) UnderlineIndex(n ColorIndex, arg interface{}) Value {
+	return r.record("UnderlineIndex", r.colorizer().UnderlineIndex(n, arg), n, arg)
+}
+
+// UnderlineGray records the call.
+func (r *Recorder) UnderlineGray(n GrayIndex, arg interface{}) Value {
+	return r.record("UnderlineGray", r.colorizer().UnderlineGray(n, arg), n, arg)
+}
+
+// NoBold records the call.
+func (r *Recorder) NoBold(arg interface{}) Value {
+	return r.record("NoBold", r.colorizer().NoBold(arg), arg)
+}
+
+// NoFaint records the call.
+func (r *Recorder) NoFaint(arg interface{}) Value {
+	return r.record("NoFaint", r.colorizer().NoFaint(arg), arg)
+}
+
+// NoItalic records the call.
+func (r *Recorder) NoItalic(arg interface{}) Value {
+	return r.record("NoItalic", r.colorizer().NoItalic(arg), arg)
+}
+
+// NoUnderline records the call.
+func (r *Recorder) NoUnderline(arg interface{}) Value {
+	return r.record("NoUnderline", r.colorizer().NoUnderline(arg), arg)
+}
+
+// NoBlink records the call.
+func (r *Recorder) NoBlink(arg interface{}) Value {
+	return r.record("NoBlink", r.colorizer().NoBlink(arg), arg)
+}
+
+// NoReverse records the call.
+func (r *Recorder) NoReverse(arg interface{}) Value {
+	return r.record("NoReverse", r.colorizer().NoReverse(arg), arg)
+}
+
+// NoConceal records the call.
+func (r *Recorder) NoConceal(arg interface{}) Value {
+	return r.record("NoConceal", r.colorizer().NoConceal(arg), arg)
+}
+
+// NoCrossedOut records the call.
+func (r *Recorder) NoCrossedOut(arg interface{}) Value {
+	return r.record("NoCrossedOut", r.colorizer().NoCrossedOut(arg), arg)
+}
+
+// NoFraktur records the call.
+func (r *Recorder) NoFraktur(arg interface{}) Value {
+	return r.record("NoFraktur", r.colorizer().NoFraktur(arg), arg)
+}
+
+// NoFramed records the call.
+func (r *Recorder) NoFramed(arg interface{}) Value {
+	return r.record("NoFramed", r.colorizer().NoFramed(arg), arg)
+}
+
+// NoEncircled records the call.
+func (r *Recorder) NoEncircled(arg interface{}) Value {
+	return r.record("NoEncircled", r.colorizer().NoEncircled(arg), arg)
+}
+
+// NoOverlined records the call.
+func (r *Recorder) NoOverlined(arg interface{}) Value {
+	return r.record("NoOverlined", r.colorizer().NoOverlined(arg), arg)
+}
+
+// NoForeground records the call.
+func (r *Recorder) NoForeground(arg interface{}) Value {
+	return r.record("NoForeground", r.colorizer().NoForeground(arg), arg)
+}
+
+// NoBackground records the call.
+func (r *Recorder) NoBackground(arg interface{}) Value {
+	return r.record("NoBackground", r.colorizer().NoBackground(arg), arg)
+}
+
+// NoUnderlineColor records the call.
+func (r *Recorder) NoUnderlineColor(arg interface{}) Value {
+	return r.record("NoUnderlineColor", r.colorizer().NoUnderlineColor(arg), arg)
+}
+
+// NoProportionalSpacing records the call.
+func (r *Recorder) NoProportionalSpacing(arg interface{}) Value {
+	return r.record("NoProportionalSpacing", r.colorizer().NoProportionalSpacing(arg), arg)
+}
+
+// NoIdeograms records the call.
+func (r *Recorder) NoIdeograms(arg interface{}) Value {
+	return r.record("NoIdeograms", r.colorizer().NoIdeograms(arg), arg)
+}
+
+// NoScript records the call.
+func (r *Recorder) NoScript(arg interface{}) Value {
+	return r.record("NoScript", r.colorizer().NoScript(arg), arg)
+}
+
+// NoFont records the call.
+func (r *Recorder) NoFont(arg interface{}) Value {
+	return r.record("NoFont", r.colorizer().NoFont(arg), arg)
+}
+
+// Without records the call.
+func (r *Recorder) Without(arg interface{}, color Color) Value {
+	return r.record("Without", r.colorizer().Without(arg, color), arg, color)
+}
+
+// Colorize records the call.
+func (r *Recorder) Colorize(arg interface{}, color Color) Value {
+	return r.record("Colorize", r.colorizer().Colorize(arg, color), arg, color)
+}
+
+// Role records the call.
+func (r *Recorder) Role(role string, arg interface{}) Value {
+	return r.record("Role", r.colorizer().Role(role, arg), role, arg)
+}
+
+// Hyperlink records the call.
+func (r *Recorder) Hyperlink(arg interface{}, target string,
+	params ...HyperlinkParam) Value {
+
+	return r.record("Hyperlink", r.colorizer().Hyperlink(arg, target,
+		params...), arg, target, params)
+}
+
+// HyperlinkTarget of underlying Colorizer. The call is not recorded.
+func (r *Recorder) HyperlinkTarget(arg interface{}) (target string) {
+	return r.colorizer().HyperlinkTarget(arg)
+}
+
+// HyperlinkParams of underlying Colorizer. The call is not recorded.
+func (r *Recorder) HyperlinkParams(arg interface{}) (
+	params []HyperlinkParam) {
+
+	return r.colorizer().HyperlinkParams(arg)
+}
+
+// Sprintf records the call.
+func (r *Recorder) Sprintf(format interface{}, args ...interface{}) string {
+	r.recordPrint("Sprintf", format, true, args)
+	return r.colorizer().Sprintf(format, args...)
+}
+
+// Sprint records the call.
+func (r *Recorder) Sprint(args ...interface{}) string {
+	r.recordPrint("Sprint", nil, false, args)
+	return r.colorizer().Sprint(args...)
+}
+
+// Sprintln records the call.
+func (r *Recorder) Sprintln(args ...interface{}) string {
+	r.recordPrint("Sprintln", nil, false, args)
+	return r.colorizer().Sprintln(args...)
+}
+
+// Fprintf records the call.
+func (r *Recorder) Fprintf(w io.Writer, format interface{},
+	args ...interface{}) (n int, err error) {
+
+	r.recordPrint("Fprintf", format, true, args)
+	return r.colorizer().Fprintf(w, format, args...)
+}
+
+// Fprint records the call.
+func (r *Recorder) Fprint(w io.Writer, args ...interface{}) (n int,
+	err error) {
+
+	r.recordPrint("Fprint", nil, false, args)
+	return r.colorizer().Fprint(w, args...)
+}
+
+// Fprintln records the call.
+func (r *Recorder) Fprintln(w io.Writer, args ...interface{}) (n int,
+	err error) {
+
+	r.recordPrint("Fprintln", nil, false, args)
+	return r.colorizer().Fprintln(w, args...)
+}
+
+// Printf records the call.
+func (r *Recorder) Printf(format interface{}, args ...interface{}) (n int,
+	err error) {
+
+	r.recordPrint("Printf", format, true, args)
+	return r.colorizer().Printf(format, args...)
+}
+
+// Print records the call.
+func (r *Recorder) Print(args ...interface{}) (n int, err error) {
+	r.recordPrint("Print", nil, false, args)
+	return r.colorizer().Print(args...)
+}
+
+// Println records the call.
+func (r *Recorder) Println(args ...interface{}) (n int, err error) {
+	r.recordPrint("Println", nil, false, args)
+	return r.colorizer().Println(args...)
+}
@@ -0,0 +1,203 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoop(t *testing.T) {
+	var c Colorizer = Noop()
+	assert.Equal(t, "x", c.Red("x").Bold().String())
+	assert.Equal(t, "x", c.Sprintf(c.Red("%s"), c.Blue("x")))
+	assert.False(t, c.Config().Colors)
+	assert.False(t, c.Config().Hyperlinks)
+}
+
+func TestRecorder(t *testing.T) {
+	var r = NewRecorder(nil)
+	assert.True(t, r.Config().Colors)
+
+	var val = r.Red("x")
+	assert.Equal(t, "\033[31mx\033[0m", val.String())
+	r.BgIndex(100, "y")
+	r.Role(RoleError, "z")
+	r.Hyperlink("link", "http://example.com", HyperlinkID("1"))
+	assert.Equal(t, "http://example.com",
+		r.HyperlinkTarget(Hyperlink("x", "http://example.com")))
+	assert.Equal(t, []HyperlinkParam{HyperlinkID("1")},
+		r.HyperlinkParams(Hyperlink("x", "http://example.com",
+			HyperlinkID("1"))))
+
+	var records = r.Records()
+	assert.Len(t, records, 4)
+	assert.Equal(t, Record{
+		Method: "Red",
+		Args:   []interface{}{"x"},
+		Value:  val,
+	}, records[0])
+	assert.Equal(t, RedFg, records[0].Color())
+	assert.Equal(t, "BgIndex", records[1].Method)
+	assert.Equal(t, []interface{}{ColorIndex(100), "y"}, records[1].Args)
+	assert.Equal(t, Color(0).BgIndex(100), records[1].Color())
+	assert.Equal(t, "Role", records[2].Method)
+	assert.Equal(t, RedFg|BoldFm, records[2].Color())
+	assert.Equal(t, "Hyperlink", records[3].Method)
+	assert.Equal(t, "http://example.com", records[3].Value.HyperlinkTarget())
+
+	r.DropRecords()
+	assert.Empty(t, r.Records())
+
+	// printing
+	var buf bytes.Buffer
+	var blue = Blue("x")
+	assert.Equal(t, "\033[31m\033[34mx\033[31m\033[0m",
+		r.Sprintf(Red("%s"), blue))
+	r.Sprint("a", 1)
+	r.Sprintln("a", 1)
+	r.Fprintf(&buf, "%d", 1)
+	r.Fprint(&buf, 1)
+	r.Fprintln(&buf, 1)
+	assert.Equal(t, "111\n", buf.String())
+	records = r.Records()
+	assert.Equal(t, []string{
+		"Sprintf", "Sprint", "Sprintln", "Fprintf", "Fprint", "Fprintln",
+	}, recordMethods(records))
+	assert.Equal(t, []interface{}{Red("%s"), blue}, records[0].Args)
+	assert.Equal(t, []interface{}{"a", 1}, records[1].Args)
+	assert.Equal(t, []interface{}{"%d", 1}, records[3].Args)
+	assert.Zero(t, records[0].Value)
+
+	// underlying colorizer
+	r = NewRecorder(Noop())
+	assert.Equal(t, "x", r.Red("x").String())
+	assert.Zero(t, r.Records()[0].Color())
+
+	// zero
+	var z Recorder
+	assert.Equal(t, "x", z.Red("x").String())
+	assert.Len(t, z.Records(), 1)
+}
+
+func recordMethods(records []Record) (methods []string) {
+	for _, r := range records {
+		methods = append(methods, r.Method)
+	}
+	return
+}
+
+func TestRecorder_methods(t *testing.T) {
+	var r = NewRecorder(nil)
+	for i, tt := range []struct {
+		name string
+		val  Value
+	}{
+		{"Reset", r.Reset("x")},
+		{"Clear", r.Clear("x")},
+		{"Bold", r.Bold("x")},
+		{"Faint", r.Faint("x")},
+		{"DoublyUnderline", r.DoublyUnderline("x")},
+		{"Fraktur", r.Fraktur("x")},
+		{"Italic", r.Italic("x")},
+		{"Underline", r.Underline("x")},
+		{"SlowBlink", r.SlowBlink("x")},
+		{"RapidBlink", r.RapidBlink("x")},
+		{"Blink", r.Blink("x")},
+		{"Reverse", r.Reverse("x")},
+		{"Inverse", r.Inverse("x")},
+		{"Conceal", r.Conceal("x")},
+		{"Hidden", r.Hidden("x")},
+		{"CrossedOut", r.CrossedOut("x")},
+		{"StrikeThrough", r.StrikeThrough("x")},
+		{"Framed", r.Framed("x")},
+		{"Encircled", r.Encircled("x")},
+		{"Overlined", r.Overlined("x")},
+		{"Black", r.Black("x")},
+		{"Red", r.Red("x")},
+		{"Green", r.Green("x")},
+		{"Yellow", r.Yellow("x")},
+		{"Blue", r.Blue("x")},
+		{"Magenta", r.Magenta("x")},
+		{"Cyan", r.Cyan("x")},
+		{"White", r.White("x")},
+		{"BrightBlack", r.BrightBlack("x")},
+		{"BrightRed", r.BrightRed("x")},
+		{"BrightGreen", r.BrightGreen("x")},
+		{"BrightYellow", r.BrightYellow("x")},
+		{"BrightBlue", r.BrightBlue("x")},
+		{"BrightMagenta", r.BrightMagenta("x")},
+		{"BrightCyan", r.BrightCyan("x")},
+		{"BrightWhite", r.BrightWhite("x")},
+		{"Index", r.Index(178, "x")},
+		{"Gray", r.Gray(14, "x")},
+		{"TrueColor", r.TrueColor(0xff8800, "x")},
+		{"BgBlack", r.BgBlack("x")},
+		{"BgRed", r.BgRed("x")},
+		{"BgGreen", r.BgGreen("x")},
+		{"BgYellow", r.BgYellow("x")},
+		{"BgBlue", r.BgBlue("x")},
+		{"BgMagenta", r.BgMagenta("x")},
+		{"BgCyan", r.BgCyan("x")},
+		{"BgWhite", r.BgWhite("x")},
+		{"BgBrightBlack", r.BgBrightBlack("x")},
+		{"BgBrightRed", r.BgBrightRed("x")},
+		{"BgBrightGreen", r.BgBrightGreen("x")},
+		{"BgBrightYellow", r.BgBrightYellow("x")},
+		{"BgBrightBlue", r.BgBrightBlue("x")},
+		{"BgBrightMagenta", r.BgBrightMagenta("x")},
+		{"BgBrightCyan", r.BgBrightCyan("x")},
+		{"BgBrightWhite", r.BgBrightWhite("x")},
+		{"BgIndex", r.BgIndex(187, "x")},
+		{"BgGray", r.BgGray(15, "x")},
+		{"BgTrueColor", r.BgTrueColor(0xff8800, "x")},
+		{"Colorize", r.Colorize("x", RedFg|BlueBg)},
+	} {
+		var rec = r.Records()[i]
+		assert.Equal(t, tt.name, rec.Method)
+		assert.Equal(t, tt.val, rec.Value, tt.name)
+		// the same as the Aurora returns
+		var in []reflect.Value
+		for _, arg := range rec.Args {
+			in = append(in, reflect.ValueOf(arg))
+		}
+		var out = reflect.ValueOf(New()).MethodByName(tt.name).Call(in)
+		assert.Equal(t, out[0].Interface(), tt.val, tt.name)
+	}
+}
@@ -0,0 +1,558 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// An RGB is 24-bit color, like 0xff8800. Values and Styles use 24-bit
+// colors with the ProfileTrueColor (38;2;r;g;b), and nearest 8-bit
+// colors otherwise, see the Index method. Functions interpreting escape
+// sequences, like the Spans and the Inspect, also reduce 24-bit colors
+// to nearest 8-bit ones.
+type RGB uint32
+
+// NewRGB returns RGB color of given red, green and blue components.
+func NewRGB(r, g, b uint8) RGB {
+	return RGB(r)<<16 | RGB(g)<<8 | RGB(b)
+}
+
+// Components returns red, green and blue components of the RGB.
+func (rgb RGB) Components() (r, g, b uint8) {
+	return uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb)
+}
+
+// Index returns nearest 8-bit color from 16 to 255. Standard and bright
+// colors are never used, since they depend on a terminal theme.
+func (rgb RGB) Index() ColorIndex {
+	var r, g, b = rgb.Components()
+	return ColorIndex(nearestExtended(r, g, b))
+}
+
+func (rgb RGB) appendHex(bs []byte) []byte {
+	const digits = "0123456789abcdef"
+	bs = append(bs, '#')
+	for shift := 20; shift >= 0; shift -= 4 {
+		bs = append(bs, digits[rgb>>uint(shift)&0xf])
+	}
+	return bs
+}
+
+// String returns hex representation of the RGB, like "#ff8800".
+func (rgb RGB) String() string {
+	return string(rgb.appendHex(make([]byte, 0, len("#rrggbb"))))
+}
+
+// MarshalText implements encoding.TextMarshaler interface. It returns
+// hex representation of the RGB, like "#ff8800".
+func (rgb RGB) MarshalText() (text []byte, err error) {
+	return rgb.appendHex(nil), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface. See the
+// ParseRGB for syntax.
+func (rgb *RGB) UnmarshalText(text []byte) (err error) {
+	var color RGB
+	if color, err = ParseRGB(string(text)); err != nil {
+		return
+	}
+	*rgb = color
+	return
+}
+
+// append 38;2;r;g;b like string, where the code is 38, 48 or 58
+func (rgb RGB) appendNos(bs []byte, code byte) []byte {
+	var r, g, b = rgb.Components()
+	bs = append(bs, code, '8', ';', '2', ';')
+	bs = append(bs, itoa(r)...)
+	bs = append(bs, ';')
+	bs = append(bs, itoa(g)...)
+	bs = append(bs, ';')
+	return append(bs, itoa(b)...)
+}
+
+// ParseHex parses hex color, like "#ff8800" or short "#f80". The leading
+// "#" is optional.
+func ParseHex(s string) (RGB, error) {
+	return parseHex(strings.TrimSpace(s))
+}
+
+// ParseRGB parses 24-bit color. The color is one of
+//
+//   - hex color, like "#ff8800" or "#f80";
+//   - RGB color, like "rgb(255, 136, 0)";
+//   - HSL color, like "hsl(32, 100%, 50%)", where hue is in degrees and
+//     saturation and lightness are in percents;
+//   - CSS (X11) color name, like "orange" or "rebeccapurple".
+//
+// The string is case-insensitive.
+func ParseRGB(s string) (rgb RGB, err error) {
+	var token = strings.ToLower(strings.TrimSpace(s))
+	var ok bool
+	if rgb, ok, err = parseTrueColor(token); !ok {
+		return 0, fmt.Errorf("unknown color %q", s)
+	}
+	return
+}
+
+// parse hex, rgb(r, g, b), hsl(h, s%, l%) or CSS color name; the ok is
+// false if the token is not a 24-bit color
+func parseTrueColor(token string) (rgb RGB, ok bool, err error) {
+	if strings.HasPrefix(token, "#") {
+		rgb, err = parseHex(token)
+		return rgb, true, err
+	}
+	if rgb, ok, err = parseRGB(token); ok {
+		return
+	}
+	if rgb, ok, err = parseHSL(token); ok {
+		return
+	}
+	rgb, ok = cssColors[token]
+	return
+}
+
+// parse #rgb or #rrggbb like token, the # is optional
+func parseHex(token string) (rgb RGB, err error) {
+	var hex = strings.TrimPrefix(token, "#")
+	if len(hex) != 3 && len(hex) != 6 {
+		return 0, fmt.Errorf("invalid hex color %q, want #rgb or #rrggbb",
+			token)
+	}
+	var u uint64
+	if u, err = strconv.ParseUint(hex, 16, 32); err != nil {
+		return 0, fmt.Errorf("invalid hex color %q, want #rgb or #rrggbb",
+			token)
+	}
+	if len(hex) == 3 {
+		// #f80 -> #ff8800
+		var r, g, b = uint8(u>>8) & 0xf, uint8(u>>4) & 0xf, uint8(u) & 0xf
+		return NewRGB(r<<4|r, g<<4|g, b<<4|b), nil
+	}
+	return RGB(u), nil
+}
+
+// arguments of name(a, b, c) like token
+func colorFuncArgs(token, name, want string) (args []string, ok bool,
+	err error) {
+
+	if !strings.HasPrefix(token, name+"(") {
+		return // not this function
+	}
+	if !strings.HasSuffix(token, ")") {
+		return nil, true, fmt.Errorf("missing closing parenthesis in %q",
+			token)
+	}
+	args = strings.Split(token[len(name)+1:len(token)-1], ",")
+	if len(args) != 3 {
+		return nil, true, fmt.Errorf("invalid %s color %q, want %s", name,
+			token, want)
+	}
+	for i := range args {
+		args[i] = strings.TrimSpace(args[i])
+	}
+	return args, true, nil
+}
+
+// parse rgb(r, g, b) like token
+func parseRGB(token string) (rgb RGB, ok bool, err error) {
+	var args []string
+	if args, ok, err = colorFuncArgs(token, "rgb", "rgb(r, g, b)"); !ok ||
+		err != nil {
+		return
+	}
+	var cs [3]uint8
+	for i, arg := range args {
+		var u uint64
+		if u, err = strconv.ParseUint(arg, 10, 8); err != nil {
+			return 0, true, fmt.Errorf("invalid rgb component %q in %q, "+
+				"want 0-255", arg, token)
+		}
+		cs[i] = uint8(u)
+	}
+	return NewRGB(cs[0], cs[1], cs[2]), true, nil
+}
+
+// parse hsl(h, s%, l%) like token
+func parseHSL(token string) (rgb RGB, ok bool, err error) {
+	var args []string
+	if args, ok, err = colorFuncArgs(token, "hsl", "hsl(h, s%, l%)"); !ok ||
+		err != nil {
+		return
+	}
+	var hsl [3]float64
+	for i, arg := range args {
+		var num = arg
+		if i == 0 {
+			num = strings.TrimSuffix(num, "deg")
+		} else {
+			num = strings.TrimSuffix(num, "%")
+		}
+		var f float64
+		f, err = strconv.ParseFloat(num, 64)
+		switch {
+		case err != nil, math.IsNaN(f), math.IsInf(f, 0):
+			return 0, true, fmt.Errorf("invalid hsl component %q in %q",
+				arg, token)
+		case i > 0 && (f < 0 || f > 100):
+			return 0, true, fmt.Errorf("invalid hsl component %q in %q, "+
+				"want 0-100%%", arg, token)
+		}
+		hsl[i] = f
+	}
+	return hslToRGB(hsl[0], hsl[1]/100, hsl[2]/100), true, nil
+}
+
+// hslToRGB converts HSL color, where the hue is in degrees and the
+// saturation and the lightness are from 0 to 1
+func hslToRGB(h, s, l float64) RGB {
+	if h = math.Mod(h, 360); h < 0 {
+		h += 360
+	}
+	var (
+		c = (1 - math.Abs(2*l-1)) * s
+		x = c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+		m = l - c/2
+
+		r, g, b float64
+	)
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	var component = func(v float64) uint8 {
+		return uint8(math.Round((v + m) * 255))
+	}
+	return NewRGB(component(r), component(g), component(b))
+}
+
+// A trueColors keeps 24-bit foreground, background and underline colors
+// of a Value or a Style, where its Color has the nearest 8-bit ones. A
+// 24-bit color is used only while the Color has its nearest 8-bit color,
+// thus methods setting standard colors or removing colors don't need to
+// reset it.
+type trueColors struct {
+	fg, bg, ul          RGB
+	hasFg, hasBg, hasUl bool
+}
+
+// copy of the trueColors, or zero trueColors for nil
+func (t *trueColors) copy() (n trueColors) {
+	if t != nil {
+		n = *t
+	}
+	return
+}
+
+// orNil returns nil if the trueColors has no colors
+func (t *trueColors) orNil() *trueColors {
+	if !t.hasFg && !t.hasBg && !t.hasUl {
+		return nil
+	}
+	return t
+}
+
+func (t *trueColors) withFg(rgb RGB) *trueColors {
+	var n = t.copy()
+	n.fg, n.hasFg = rgb, true
+	return &n
+}
+
+func (t *trueColors) withBg(rgb RGB) *trueColors {
+	var n = t.copy()
+	n.bg, n.hasBg = rgb, true
+	return &n
+}
+
+func (t *trueColors) withUl(rgb RGB) *trueColors {
+	var n = t.copy()
+	n.ul, n.hasUl = rgb, true
+	return &n
+}
+
+// noFg drops 24-bit foreground color, e.g. when an 8-bit color set
+func (t *trueColors) noFg() *trueColors {
+	var n = t.copy()
+	n.fg, n.hasFg = 0, false
+	return n.orNil()
+}
+
+// noBg drops 24-bit background color, e.g. when an 8-bit color set
+func (t *trueColors) noBg() *trueColors {
+	var n = t.copy()
+	n.bg, n.hasBg = 0, false
+	return n.orNil()
+}
+
+// noUl drops 24-bit underline color, e.g. when an 8-bit color set
+func (t *trueColors) noUl() *trueColors {
+	var n = t.copy()
+	n.ul, n.hasUl = 0, false
+	return n.orNil()
+}
+
+// merge returns the trueColors with 24-bit colors of the over replacing
+// the ones given Color sets, that is Color of the over
+func (t *trueColors) merge(over *trueColors, c Color) *trueColors {
+	var n, o = t.copy(), over.copy()
+	if c&flagFg != 0 {
+		n.fg, n.hasFg = o.fg, o.hasFg
+	}
+	if c&flagBg != 0 {
+		n.bg, n.hasBg = o.bg, o.hasBg
+	}
+	if c&flagUl != 0 {
+		n.ul, n.hasUl = o.ul, o.hasUl
+	}
+	return n.orNil()
+}
+
+// active reports which 24-bit colors are used with given Color
+func (t *trueColors) active(c Color) (fg, bg, ul bool) {
+	if t == nil {
+		return
+	}
+	if n, ok := c.Foreground(); ok && t.hasFg {
+		fg = n == t.fg.Index()
+	}
+	if n, ok := c.Background(); ok && t.hasBg {
+		bg = n == t.bg.Index()
+	}
+	if n, ok := c.UnderlineColor(); ok && t.hasUl {
+		ul = n == t.ul.Index()
+	}
+	return
+}
+
+// appendSequence appends SGR sequence of given Color, replacing its 8-bit
+// foreground, background and underline colors with active 24-bit ones
+func (t *trueColors) appendSequence(bs []byte, c Color, zero bool) []byte {
+	var fg, bg, ul = t.active(c)
+	if !fg && !bg && !ul {
+		if zero {
+			return append(bs, c.zeroSequence()...)
+		}
+		return append(bs, c.Sequence()...)
+	}
+	if fg {
+		c &^= flagFg | maskFg
+	}
+	if bg {
+		c &^= flagBg | maskBg
+	}
+	if ul {
+		c &^= maskUl
+	}
+	bs = append(bs, esc...)
+	var start = len(bs)
+	bs = c.appendNos(bs, zero)
+	if fg {
+		bs = t.fg.appendNos(appendSemi(bs, len(bs) > start), '3')
+	}
+	if bg {
+		bs = t.bg.appendNos(appendSemi(bs, len(bs) > start), '4')
+	}
+	if ul {
+		bs = t.ul.appendNos(appendSemi(bs, len(bs) > start), '5')
+	}
+	return append(bs, 'm')
+}
+
+// CSS (X11) color names
+var cssColors = map[string]RGB{
+	"aliceblue":            0xf0f8ff,
+	"antiquewhite":         0xfaebd7,
+	"aqua":                 0x00ffff,
+	"aquamarine":           0x7fffd4,
+	"azure":                0xf0ffff,
+	"beige":                0xf5f5dc,
+	"bisque":               0xffe4c4,
+	"black":                0x000000,
+	"blanchedalmond":       0xffebcd,
+	"blue":                 0x0000ff,
+	"blueviolet":           0x8a2be2,
+	"brown":                0xa52a2a,
+	"burlywood":            0xdeb887,
+	"cadetblue":            0x5f9ea0,
+	"chartreuse":           0x7fff00,
+	"chocolate":            0xd2691e,
+	"coral":                0xff7f50,
+	"cornflowerblue":       0x6495ed,
+	"cornsilk":             0xfff8dc,
+	"crimson":              0xdc143c,
+	"cyan":                 0x00ffff,
+	"darkblue":             0x00008b,
+	"darkcyan":             0x008b8b,
+	"darkgoldenrod":        0xb8860b,
+	"darkgray":             0xa9a9a9,
+	"darkgreen":            0x006400,
+	"darkgrey":             0xa9a9a9,
+	"darkkhaki":            0xbdb76b,
+	"darkmagenta":          0x8b008b,
+	"darkolivegreen":       0x556b2f,
+	"darkorange":           0xff8c00,
+	"darkorchid":           0x9932cc,
+	"darkred":              0x8b0000,
+	"darksalmon":           0xe9967a,
+	"darkseagreen":         0x8fbc8f,
+	"darkslateblue":        0x483d8b,
+	"darkslategray":        0x2f4f4f,
+	"darkslategrey":        0x2f4f4f,
+	"darkturquoise":        0x00ced1,
+	"darkviolet":           0x9400d3,
+	"deeppink":             0xff1493,
+	"deepskyblue":          0x00bfff,
+	"dimgray":              0x696969,
+	"dimgrey":              0x696969,
+	"dodgerblue":           0x1e90ff,
+	"firebrick":            0xb22222,
+	"floralwhite":          0xfffaf0,
+	"forestgreen":          0x228b22,
+	"fuchsia":              0xff00ff,
+	"gainsboro":            0xdcdcdc,
+	"ghostwhite":           0xf8f8ff,
+	"gold":                 0xffd700,
+	"goldenrod":            0xdaa520,
+	"gray":                 0x808080,
+	"green":                0x008000,
+	"greenyellow":          0xadff2f,
+	"grey":                 0x808080,
+	"honeydew":             0xf0fff0,
+	"hotpink":              0xff69b4,
+	"indianred":            0xcd5c5c,
+	"indigo":               0x4b0082,
+	"ivory":                0xfffff0,
+	"khaki":                0xf0e68c,
+	"lavender":             0xe6e6fa,
+	"lavenderblush":        0xfff0f5,
+	"lawngreen":            0x7cfc00,
+	"lemonchiffon":         0xfffacd,
+	"lightblue":            0xadd8e6,
+	"lightcoral":           0xf08080,
+	"lightcyan":            0xe0ffff,
+	"lightgoldenrodyellow": 0xfafad2,
+	"lightgray":            0xd3d3d3,
+	"lightgreen":           0x90ee90,
+	"lightgrey":            0xd3d3d3,
+	"lightpink":            0xffb6c1,
+	"lightsalmon":          0xffa07a,
+	"lightseagreen":        0x20b2aa,
+	"lightskyblue":         0x87cefa,
+	"lightslategray":       0x778899,
+	"lightslategrey":       0x778899,
+	"lightsteelblue":       0xb0c4de,
+	"lightyellow":          0xffffe0,
+	"lime":                 0x00ff00,
+	"limegreen":            0x32cd32,
+	"linen":                0xfaf0e6,
+	"magenta":              0xff00ff,
+	"maroon":               0x800000,
+	"mediumaquamarine":     0x66cdaa,
+	"mediumblue":           0x0000cd,
+	"mediumorchid":         0xba55d3,
+	"mediumpurple":         0x9370db,
+	"mediumseagreen":       0x3cb371,
+	"mediumslateblue":      0x7b68ee,
+	"mediumspringgreen":    0x00fa9a,
+	"mediumturquoise":      0x48d1cc,
+	"mediumvioletred":      0xc71585,
+	"midnightblue":         0x191970,
+	"mintcream":            0xf5fffa,
+	"mistyrose":            0xffe4e1,
+	"moccasin":             0xffe4b5,
+	"navajowhite":          0xffdead,
+	"navy":                 0x000080,
+	"oldlace":              0xfdf5e6,
+	"olive":                0x808000,
+	"olivedrab":            0x6b8e23,
+	"orange":               0xffa500,
+	"orangered":            0xff4500,
+	"orchid":               0xda70d6,
+	"palegoldenrod":        0xeee8aa,
+	"palegreen":            0x98fb98,
+	"paleturquoise":        0xafeeee,
+	"palevioletred":        0xdb7093,
+	"papayawhip":           0xffefd5,
+	"peachpuff":            0xffdab9,
+	"peru":                 0xcd853f,
+	"pink":                 0xffc0cb,
+	"plum":                 0xdda0dd,
+	"powderblue":           0xb0e0e6,
+	"purple":               0x800080,
+	"rebeccapurple":        0x663399,
+	"red":                  0xff0000,
+	"rosybrown":            0xbc8f8f,
+	"royalblue":            0x4169e1,
+	"saddlebrown":          0x8b4513,
+	"salmon":               0xfa8072,
+	"sandybrown":           0xf4a460,
+	"seagreen":             0x2e8b57,
+	"seashell":             0xfff5ee,
+	"sienna":               0xa0522d,
+	"silver":               0xc0c0c0,
+	"skyblue":              0x87ceeb,
+	"slateblue":            0x6a5acd,
+	"slategray":            0x708090,
+	"slategrey":            0x708090,
+	"snow":                 0xfffafa,
+	"springgreen":          0x00ff7f,
+	"steelblue":            0x4682b4,
+	"tan":                  0xd2b48c,
+	"teal":                 0x008080,
+	"thistle":              0xd8bfd8,
+	"tomato":               0xff6347,
+	"turquoise":            0x40e0d0,
+	"violet":               0xee82ee,
+	"wheat":                0xf5deb3,
+	"white":                0xffffff,
+	"whitesmoke":           0xf5f5f5,
+	"yellow":               0xffff00,
+	"yellowgreen":          0x9acd32,
+}
@@ -0,0 +1,271 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRGB(t *testing.T) {
+	var rgb = NewRGB(255, 136, 0)
+	assert.Equal(t, RGB(0xff8800), rgb)
+	var r, g, b = rgb.Components()
+	assert.Equal(t, []uint8{255, 136, 0}, []uint8{r, g, b})
+	assert.Equal(t, ColorIndex(208), rgb.Index())
+	assert.Equal(t, ColorIndex(16), RGB(0).Index())
+	assert.Equal(t, ColorIndex(231), RGB(0xffffff).Index())
+	assert.Equal(t, "#ff8800", rgb.String())
+	assert.Equal(t, "#000000", RGB(0).String())
+	assert.Equal(t, Color(0).Index(208), Color(0).TrueColor(rgb))
+	assert.Equal(t, Color(0).BgIndex(208), Color(0).BgTrueColor(rgb))
+}
+
+func TestRGB_MarshalText(t *testing.T) {
+	var text, err = RGB(0x663399).MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "#663399", string(text))
+	var got RGB
+	require.NoError(t, got.UnmarshalText([]byte("RebeccaPurple")))
+	assert.Equal(t, RGB(0x663399), got)
+	assert.Error(t, got.UnmarshalText([]byte("pinkish")))
+	assert.Equal(t, RGB(0x663399), got, "changed on error")
+}
+
+func TestParseHex(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want RGB
+	}{
+		{"#ff8800", 0xff8800},
+		{"#FF8800", 0xff8800},
+		{"ff8800", 0xff8800},
+		{"#f80", 0xff8800},
+		{" #000 ", 0x000000},
+	} {
+		var rgb, err = ParseHex(tt.s)
+		require.NoError(t, err, tt.s)
+		assert.Equal(t, tt.want, rgb, tt.s)
+	}
+	for _, s := range []string{"", "#", "#ff88", "#xyz", "#+f80", "#ff8800ff"} {
+		var _, err = ParseHex(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseRGB(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want RGB
+	}{
+		{"#ff8800", 0xff8800},
+		{"#f80", 0xff8800},
+		{"rgb(255,136,0)", 0xff8800},
+		{"RGB( 255 , 136 , 0 )", 0xff8800},
+		{"hsl(32, 100%, 50%)", 0xff8800},
+		{"hsl(0, 0%, 50%)", 0x808080},
+		{"hsl(120, 100%, 25%)", 0x008000},
+		{"hsl(-120, 100, 50)", 0x0000ff},
+		{"hsl(360deg, 100%, 50%)", 0xff0000},
+		{"hsl(0.5, 100%, 100%)", 0xffffff},
+		{"orange", 0xffa500},
+		{"RebeccaPurple", 0x663399},
+		{"red", 0xff0000},
+		{"grey", 0x808080},
+	} {
+		var rgb, err = ParseRGB(tt.s)
+		require.NoError(t, err, tt.s)
+		assert.Equal(t, tt.want, rgb, tt.s)
+	}
+	for _, tt := range []struct {
+		s, err string
+	}{
+		{"pinkish", `unknown color "pinkish"`},
+		{"", `unknown color ""`},
+		{"#ff88", `invalid hex color "#ff88", want #rgb or #rrggbb`},
+		{"rgb(1, 2)", `invalid rgb color "rgb(1, 2)", want rgb(r, g, b)`},
+		{"hsl(1, 2%)", `invalid hsl color "hsl(1, 2%)", want hsl(h, s%, l%)`},
+		{"hsl(1, 2%, 3%", `missing closing parenthesis in "hsl(1, 2%, 3%"`},
+		{"hsl(x, 2%, 3%)", `invalid hsl component "x" in "hsl(x, 2%, 3%)"`},
+		{"hsl(inf, 2%, 3%)",
+			`invalid hsl component "inf" in "hsl(inf, 2%, 3%)"`},
+		{"hsl(1, 200%, 3%)", `invalid hsl component "200%" in ` +
+			`"hsl(1, 200%, 3%)", want 0-100%`},
+	} {
+		var _, err = ParseRGB(tt.s)
+		assert.EqualError(t, err, tt.err, tt.s)
+	}
+}
+
+func Test_cssColors(t *testing.T) {
+	assert.Len(t, cssColors, 148)
+	for name, rgb := range cssColors {
+		var got, err = ParseRGB(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, rgb, got, name)
+	}
+}
+
+func TestValue_TrueColor(t *testing.T) {
+	var (
+		tc  = New(WithProfile(ProfileTrueColor))
+		a16 = New(WithProfile(ProfileANSI16))
+	)
+	for _, tt := range []struct {
+		name string
+		val  Value
+		want string
+	}{
+		{"fg", tc.TrueColor(0xff8800, "x").Bold(),
+			"\033[1;38;2;255;136;0mx\033[0m"},
+		{"fg bg", tc.TrueColor(0xff8800, "x").BgTrueColor(0x0000ff),
+			"\033[38;2;255;136;0;48;2;0;0;255mx\033[0m"},
+		{"bg", tc.BgTrueColor(0xff8800, tc.Red("x")),
+			"\033[31;48;2;255;136;0mx\033[0m"},
+		{"ansi256", New().TrueColor(0xff8800, "x").Bold(),
+			"\033[1;38;5;208mx\033[0m"},
+		{"ansi16", a16.TrueColor(0xff8800, "x"),
+			a16.Index(208, "x").String()},
+		{"replaced", tc.TrueColor(0xff8800, "x").Red(), "\033[31mx\033[0m"},
+		{"replaced by index", tc.TrueColor(0xff8800, "x").Index(208),
+			"\033[38;5;208mx\033[0m"},
+		{"replaced by bg index",
+			tc.TrueColor(0xff8800, "x").BgTrueColor(1).BgIndex(16),
+			"\033[48;5;16;38;2;255;136;0mx\033[0m"},
+		{"removed", tc.TrueColor(0xff8800, "x").NoForeground(), "x"},
+		{"colorized", tc.TrueColor(0xff8800, "x").Colorize(0).Index(208),
+			"\033[38;5;208mx\033[0m"},
+		{"disabled", New(WithColors(false)).TrueColor(0xff8800, "x"), "x"},
+	} {
+		assert.Equal(t, tt.want, tt.val.String(), tt.name)
+	}
+	var v = tc.TrueColor(0xff8800, "x")
+	assert.Equal(t, "\033[38;2;255;136;0m    x\033[0m", fmt.Sprintf("%5s", v))
+	assert.Equal(t, Color(0).Index(208), v.Color())
+	// transformed
+	assert.Equal(t, "\033[38;2;255;136;0mx\033[0m",
+		tc.Sprint(New().TrueColor(0xff8800, "x")))
+}
+
+func TestValue_TrueColor_sprintf(t *testing.T) {
+	var tc = New(WithProfile(ProfileTrueColor))
+	assert.Equal(t, "\033[31ma \033[0;38;2;255;136;0mx\033[0;31m b\033[0m",
+		tc.Sprintf(tc.Red("a %s b"), tc.TrueColor(0xff8800, "x")))
+	assert.Equal(t, "\033[38;2;255;136;0ma \033[0;31mx"+
+		"\033[0;38;2;255;136;0m b\033[0m",
+		tc.Sprintf(tc.TrueColor(0xff8800, "a %s b"), tc.Red("x")))
+	// 8-bit colors with other profiles
+	assert.Equal(t, "\033[31ma \033[38;5;208mx\033[31m b\033[0m",
+		Sprintf(Red("a %s b"), TrueColor(0xff8800, "x")))
+}
+
+func TestStyle_TrueColor(t *testing.T) {
+	var style = NewStyle().TrueColor(0xff8800).BgTrueColor(0x663399).Bold().
+		WithOptions(WithProfile(ProfileTrueColor))
+	assert.Equal(t, "\033[1;38;2;255;136;0;48;2;102;51;153mx\033[0m",
+		style.Apply("x").String())
+	assert.Equal(t, "\033[1;38;2;255;136;0;48;2;102;51;153mx\033[0m",
+		style.Apply(Red("x")).String())
+	assert.Equal(t, "bold #ff8800 on #663399", style.String())
+	assert.Equal(t, "bold color(208) on #663399",
+		style.Index(208).String())
+	assert.Equal(t, "", style.Colorize(0).String())
+	// round trip
+	var got, err = ParseStyle(style.String())
+	require.NoError(t, err)
+	assert.Equal(t, style.WithColorizer(nil), got)
+	// CSS names are 24-bit, standard names are not
+	got, err = ParseStyle("orange on red")
+	require.NoError(t, err)
+	assert.Equal(t, NewStyle().TrueColor(0xffa500).Red().BgRed().
+		TrueColor(0xffa500), got)
+	assert.Equal(t, "#ffa500 on red", got.String())
+}
+
+func TestStyle_TrueColor_underline(t *testing.T) {
+	var style, err = ParseStyle("underline under #ff0000")
+	require.NoError(t, err)
+	assert.Equal(t, "underline under #ff0000", style.String())
+	assert.Equal(t, "\033[4;58;2;255;0;0mx\033[0m", style.
+		WithOptions(WithProfile(ProfileTrueColor)).Apply("x").String())
+	assert.Equal(t, "\033[4;58;5;196mx\033[0m", style.Apply("x").String())
+	assert.Equal(t, "underline under color(196)",
+		style.UnderlineIndex(196).String())
+	// round trip
+	var got Style
+	got, err = ParseStyle(style.String())
+	require.NoError(t, err)
+	assert.Equal(t, style, got)
+	// values
+	var v = style.WithOptions(WithProfile(ProfileTrueColor)).Apply("x")
+	assert.Equal(t, "\033[4;58;5;196mx\033[0m",
+		v.UnderlineIndex(196).String())
+	assert.Equal(t, "\033[4;58;5;196m    x\033[0m",
+		fmt.Sprintf("%5s", v.UnderlineIndex(196)))
+	assert.Equal(t, "\033[4;58;2;255;0;0m    x\033[0m", fmt.Sprintf("%5s", v))
+}
+
+func TestStyle_Merge(t *testing.T) {
+	var s = NewStyle().Bold().TrueColor(0xff8800).BgTrueColor(0x663399)
+	assert.Equal(t, s, s.Merge(NewStyle()))
+	assert.Equal(t, "bold italic red on #663399",
+		s.Merge(NewStyle().Italic().Red()).String())
+	assert.Equal(t, "bold #0000ff on #663399",
+		s.Merge(NewStyle().TrueColor(0x0000ff)).String())
+	assert.Equal(t, "bold color(208) on #663399",
+		s.Merge(NewStyle().Index(208)).String()) // the same nearest color
+	var under, err = ParseStyle("under #ff0000")
+	require.NoError(t, err)
+	assert.Equal(t, "bold #ff8800 on #663399 under #ff0000",
+		s.Merge(under).String())
+	// hyperlinks
+	var link = s.Hyperlink("http://x/")
+	assert.Equal(t, "http://x/", link.Merge(NewStyle()).HyperlinkTarget())
+	assert.Equal(t, "http://y/",
+		link.Merge(NewStyle().Hyperlink("http://y/")).HyperlinkTarget())
+	// values
+	var tc = New(WithProfile(ProfileTrueColor))
+	var v = tc.Red("x").Merge(s)
+	assert.Equal(t, "\033[1;38;2;255;136;0;48;2;102;51;153mx\033[0m",
+		v.String())
+	assert.Equal(t, s, v.Style())
+	assert.Equal(t, "\033[1;31;48;2;102;51;153mx\033[0m",
+		v.Merge(NewStyle().Red()).String())
+	assert.Equal(t, NewStyle(), tc.Reset("x").Style())
+}
@@ -0,0 +1,224 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// maximum number of cached sequences, all other
+// sequences will be built every time
+const maxCachedSequences = 4096
+
+// A sequenceCache is concurrency safe cache of SGR sequences, keyed by a
+// Color. Lookups don't lock, since a sequence of a Color never changes.
+type sequenceCache struct {
+	zero bool         // 0; prefix
+	size atomic.Int64 // number of cached sequences
+	seqs sync.Map     // Color -> string
+}
+
+func newSequenceCache(zero bool) *sequenceCache {
+	return &sequenceCache{zero: zero}
+}
+
+func (s *sequenceCache) get(c Color) string {
+	if seq, ok := s.seqs.Load(c); ok {
+		return seq.(string)
+	}
+	var bs = make([]byte, 0, len(esc)+59+len("m"))
+	bs = append(bs, esc...)
+	bs = c.appendNos(bs, s.zero)
+	bs = append(bs, 'm')
+	var seq = string(bs)
+	if s.size.Load() < maxCachedSequences {
+		if _, loaded := s.seqs.LoadOrStore(c, seq); !loaded {
+			s.size.Add(1)
+		}
+	}
+	return seq
+}
+
+var (
+	sequences     = newSequenceCache(false) // \033[1;31m
+	zeroSequences = newSequenceCache(true)  // \033[0;1;31m
+)
+
+// Sequence returns full SGR escape sequence of the Color, for example
+// "\033[1;31m". It returns empty string for the zero Color. Sequences are
+// cached, thus subsequent calls for the same Color are cheap. It's safe
+// for concurrent use.
+func (c Color) Sequence() string {
+	if c == 0 {
+		return ""
+	}
+	return sequences.get(c)
+}
+
+// zeroSequence is like the Sequence, but the sequence resets all
+// previous colors and formats, e.g. "\033[0;1;31m"; it returns
+// "\033[0m" for zero Color
+func (c Color) zeroSequence() string {
+	if c == 0 {
+		return clear
+	}
+	return zeroSequences.get(c)
+}
+
+// groups of formats sharing the same off-code; formats of a group can't
+// be turned off separately
+var offGroups = [...]struct {
+	mask Color
+	off  string
+}{
+	{BoldFm | FaintFm, "22"},
+	{ItalicFm | FrakturFm, "23"},
+	{maskUnderline, "24"},
+	{SlowBlinkFm | RapidBlinkFm, "25"},
+	{ReverseFm, "27"},
+	{ConcealFm, "28"},
+	{CrossedOutFm, "29"},
+	{FramedFm | EncircledFm, "54"},
+	{OverlinedFm, "55"},
+	{ProportionalSpacingFm, "50"},
+	{maskIdeogram, "65"},
+	{maskScript, "75"},
+}
+
+// effective returns the Color without formats hidden by other ones
+// (see appendNos), e.g. faint is not used with bold
+func (c Color) effective() Color {
+	if c&BoldFm != 0 {
+		c &^= FaintFm
+	}
+	if c&SlowBlinkFm != 0 {
+		c &^= RapidBlinkFm
+	}
+	if c&SuperscriptFm != 0 {
+		c &^= SubscriptFm
+	}
+	return c
+}
+
+// appendTransition appends SGR parameters changing the c to given Color
+// using off-codes, without the "0" reset; it appends nothing for equal
+// colors
+func (c Color) appendTransition(bs []byte, to Color) []byte {
+	var (
+		from  = c.effective()
+		start = len(bs)
+	)
+	to = to.effective()
+	var appendCodes = func(codes Color) {
+		if len(bs) > start {
+			bs = append(bs, ';')
+		}
+		bs = codes.appendNos(bs, false)
+	}
+	for _, g := range offGroups {
+		var a, b = from & g.mask, to & g.mask
+		switch {
+		case a == b:
+			continue
+		case a&^b == 0:
+			appendCodes(b &^ a) // only add missing ones
+			continue
+		}
+		if len(bs) > start {
+			bs = append(bs, ';')
+		}
+		bs = append(bs, g.off...)
+		if b != 0 {
+			appendCodes(b) // restore rest of the group
+		}
+	}
+	if a, b := from&maskFg, to&maskFg; a != b {
+		if b == 0 {
+			bs = appendSemi(bs, len(bs) > start, '3', '9')
+		} else {
+			appendCodes(b)
+		}
+	}
+	if a, b := from&maskBg, to&maskBg; a != b {
+		if b == 0 {
+			bs = appendSemi(bs, len(bs) > start, '4', '9')
+		} else {
+			appendCodes(b)
+		}
+	}
+	if a, b := from&maskFont, to&maskFont; a != b {
+		if b == 0 {
+			bs = appendSemi(bs, len(bs) > start, '1', '0')
+		} else {
+			appendCodes(b)
+		}
+	}
+	if a, b := from&maskUl, to&maskUl; a != b {
+		if b == 0 {
+			bs = appendSemi(bs, len(bs) > start, '5', '9')
+		} else {
+			appendCodes(b)
+		}
+	}
+	return bs
+}
+
+// Transition returns the shortest SGR escape sequence changing the Color
+// to given one. Unlike the Sequence, it turns off formats and colors
+// absent in the to Color using targeted off-codes, like 22 or 39, instead
+// of resetting everything, e.g.
+//
+//	(BoldFm | RedFg).Transition(BlueFg) // "\033[22;34m"
+//
+// It falls back to full reset, like "\033[0;34m", when it's shorter. It
+// returns empty string for equal colors.
+func (c Color) Transition(to Color) string {
+	if c == to {
+		return ""
+	}
+	var bs = make([]byte, 0, len(esc)+59+len("m"))
+	bs = append(bs, esc...)
+	bs = c.appendTransition(bs, to)
+	if len(bs) == len(esc) {
+		return "" // visually equal colors
+	}
+	bs = append(bs, 'm')
+	if zero := to.zeroSequence(); len(zero) < len(bs) {
+		return zero
+	}
+	return string(bs)
+}
@@ -0,0 +1,300 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"strconv"
+	"strings"
+)
+
+// formats by SGR codes turning them on
+var sgrFormats = map[int]Color{
+	1:  BoldFm,
+	2:  FaintFm,
+	3:  ItalicFm,
+	4:  UnderlineFm,
+	5:  SlowBlinkFm,
+	6:  RapidBlinkFm,
+	7:  ReverseFm,
+	8:  ConcealFm,
+	9:  CrossedOutFm,
+	20: FrakturFm,
+	21: DoublyUnderlineFm,
+	51: FramedFm,
+	52: EncircledFm,
+	53: OverlinedFm,
+	26: ProportionalSpacingFm,
+	60: IdeogramUnderlineFm,
+	61: IdeogramDoubleUnderlineFm,
+	62: IdeogramOverlineFm,
+	63: IdeogramDoubleOverlineFm,
+	64: IdeogramStressFm,
+	73: SuperscriptFm,
+	74: SubscriptFm,
+}
+
+// formats by SGR codes turning them off
+var sgrOffFormats = map[int]Color{
+	22: BoldFm | FaintFm,
+	23: ItalicFm | FrakturFm,
+	24: maskUnderline,
+	25: SlowBlinkFm | RapidBlinkFm,
+	27: ReverseFm,
+	28: ConcealFm,
+	29: CrossedOutFm,
+	54: FramedFm | EncircledFm,
+	55: OverlinedFm,
+	50: ProportionalSpacingFm,
+	65: maskIdeogram,
+	75: maskScript,
+}
+
+// parse SGR parameter, where empty parameter is zero
+func sgrAtoi(s string) (n int) {
+	n, _ = strconv.Atoi(s)
+	return
+}
+
+// extended color (38, 48) arguments, like 5;n or 2;r;g;b; it returns
+// 8-bit color and number of used arguments
+func sgrExtColor(args []string) (n uint8, used int, ok bool) {
+	if len(args) == 0 {
+		return
+	}
+	switch sgrAtoi(args[0]) {
+	case 5:
+		if len(args) < 2 {
+			return 0, len(args), false
+		}
+		return uint8(sgrAtoi(args[1])), 2, true
+	case 2:
+		if len(args) < 4 {
+			return 0, len(args), false
+		}
+		var rgb = args[1:4]
+		if len(args) >= 5 && rgb[0] == "" {
+			rgb = args[2:5] // colon form with color space id, 2::r:g:b
+			used++
+		}
+		var r, g, b = sgrAtoi(rgb[0]), sgrAtoi(rgb[1]), sgrAtoi(rgb[2])
+		return nearestIndex(uint8(r), uint8(g), uint8(b), 256), used + 4, true
+	}
+	return 0, 1, false
+}
+
+// underline style by 4:n sub-parameter
+func (c Color) applyUnderlineStyle(n int) Color {
+	switch n {
+	case 0:
+		return c &^ maskUnderline
+	case 1:
+		return c.Underline()
+	case 2:
+		return c.DoublyUnderline()
+	case 3:
+		return c.CurlyUnderline()
+	case 4:
+		return c.DottedUnderline()
+	case 5:
+		return c.DashedUnderline()
+	}
+	return c
+}
+
+// ApplySGR returns the Color with given SGR parameters applied. The
+// parameters are part of an SGR sequence between the "\033[" and the "m",
+// like "0;1;31" or "38;5;100". Unknown parameters are ignored. 24-bit
+// colors are replaced with nearest 8-bit ones, thus 24-bit colors with the
+// same nearest color can't be told apart.
+func (c Color) ApplySGR(params string) Color {
+	var ps = strings.Split(params, ";")
+	for i := 0; i < len(ps); i++ {
+		var (
+			sub  = strings.Split(ps[i], ":")
+			code = sgrAtoi(sub[0])
+		)
+		switch {
+		case code == 0:
+			c = 0
+		case code == 4 && len(sub) > 1:
+			c = c.applyUnderlineStyle(sgrAtoi(sub[1]))
+		case sgrFormats[code] != 0:
+			c |= sgrFormats[code]
+		case sgrOffFormats[code] != 0:
+			c &^= sgrOffFormats[code]
+		case 10 <= code && code <= 19:
+			c = c.Font(FontIndex(code - 10))
+		case 30 <= code && code <= 37:
+			c = c.Index(ColorIndex(code - 30))
+		case 90 <= code && code <= 97:
+			c = c.Index(ColorIndex(code - 90 + 8))
+		case 40 <= code && code <= 47:
+			c = c.BgIndex(ColorIndex(code - 40))
+		case 100 <= code && code <= 107:
+			c = c.BgIndex(ColorIndex(code - 100 + 8))
+		case code == 39:
+			c &^= maskFg
+		case code == 49:
+			c &^= maskBg
+		case code == 59:
+			c &^= maskUl
+		case code == 38, code == 48, code == 58:
+			var (
+				n        uint8
+				used     int
+				ok       bool
+				colonArg = len(sub) > 1
+			)
+			if colonArg {
+				n, _, ok = sgrExtColor(sub[1:])
+			} else {
+				n, used, ok = sgrExtColor(ps[i+1:])
+				i += used
+			}
+			if !ok {
+				continue
+			}
+			switch code {
+			case 38:
+				c = c.Index(ColorIndex(n))
+			case 48:
+				c = c.BgIndex(ColorIndex(n))
+			default:
+				c = c.UnderlineIndex(ColorIndex(n))
+			}
+		}
+	}
+	return c
+}
+
+// sgrParams returns parameters of given escape sequence if it's an SGR
+func sgrParams(seq string) (params string, ok bool) {
+	if len(seq) < 3 || seq[1] != '[' || seq[len(seq)-1] != 'm' {
+		return
+	}
+	params = seq[2 : len(seq)-1]
+	for i := 0; i < len(params); i++ {
+		if (params[i] < '0' || params[i] > '9') && params[i] != ';' &&
+			params[i] != ':' {
+			return "", false // private or malformed
+		}
+	}
+	return params, true
+}
+
+// oscLink returns hyperlink of given escape sequence if it's an OSC 8;
+// the target is empty for closing sequence
+func oscLink(seq string) (target string, params []HyperlinkParam, ok bool) {
+	if !strings.HasPrefix(seq, linkStartEsc) {
+		return
+	}
+	var body = seq[len(linkStartEsc):]
+	switch {
+	case strings.HasSuffix(body, linkMiddleEsc):
+		body = body[:len(body)-len(linkMiddleEsc)]
+	case strings.HasSuffix(body, "\a"):
+		body = body[:len(body)-1]
+	default:
+		return // unterminated
+	}
+	var i = strings.IndexByte(body, ';')
+	if i < 0 {
+		return
+	}
+	if i > 0 {
+		for _, kv := range strings.Split(body[:i], ":") {
+			var k, v, _ = strings.Cut(kv, "=")
+			params = append(params, HyperlinkParam{Key: k, Value: v})
+		}
+	}
+	return body[i+1:], params, true
+}
+
+// A Span is a part of a string with the same colors, formats and
+// hyperlink.
+type Span struct {
+	Text   string           // text without escape sequences
+	Color  Color            // colors and formats
+	Link   string           // hyperlink target, if any
+	Params []HyperlinkParam // hyperlink parameters
+}
+
+func (s *Span) sameStyle(o *Span) bool {
+	if s.Color != o.Color || s.Link != o.Link ||
+		len(s.Params) != len(o.Params) {
+		return false
+	}
+	for i := range s.Params {
+		if s.Params[i] != o.Params[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Spans splits given string to spans of text with the same colors,
+// formats and hyperlinks, interpreting SGR and OSC 8 escape sequences.
+// Adjacent spans of the same style are merged, and empty spans are
+// dropped. Other escape sequences are skipped. Thus, two strings with
+// different sequences but the same look have equal spans. 24-bit colors
+// are replaced with nearest 8-bit ones, see the ApplySGR.
+func Spans(s string) (spans []Span) {
+	var cur Span
+	for i := 0; i < len(s); {
+		if s[i] != '\033' {
+			var j = strings.IndexByte(s[i:], '\033')
+			if j < 0 {
+				j = len(s) - i
+			}
+			cur.Text = s[i : i+j]
+			if n := len(spans); n > 0 && spans[n-1].sameStyle(&cur) {
+				spans[n-1].Text += cur.Text
+			} else {
+				spans = append(spans, cur)
+			}
+			i += j
+			continue
+		}
+		var seq = s[i : i+escapeLen(s[i:])]
+		i += len(seq)
+		if params, ok := sgrParams(seq); ok {
+			cur.Color = cur.Color.ApplySGR(params)
+		} else if target, params, ok := oscLink(seq); ok {
+			cur.Link, cur.Params = target, params
+		}
+	}
+	return
+}
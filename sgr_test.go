@@ -0,0 +1,151 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColor_ApplySGR(t *testing.T) {
+	for _, tt := range []struct {
+		c      Color
+		params string
+		want   Color
+	}{
+		{0, "", 0},
+		{RedFg, "", 0},
+		{RedFg, "0", 0},
+		{0, "1;31", BoldFm | RedFg},
+		{0, "1;2;3;4;5;6;7;8;9;20;21;51;52;53", 1<<14 - 1},
+		{0, "26;60;61;62;63;64;73;74",
+			ProportionalSpacingFm | maskIdeogram | maskScript},
+		{maskFm, "22;23;24;25;27;28;29;54;55;50;65;75", 0},
+		{0, "13;1", Color(0).Font(3) | BoldFm},
+		{Color(0).Font(3), "19", Color(0).Font(9)},
+		{Color(0).Font(3) | BoldFm, "10", BoldFm},
+		{BoldFm | RedFg, "0;34", BlueFg},
+		{0, "91;102", RedFg | BrightFg | GreenBg | BrightBg},
+		{RedFg | GreenBg, "39", GreenBg},
+		{RedFg | GreenBg, "49", RedFg},
+		{0, "38;5;100;48;5;200;1", Color(0).Index(100).BgIndex(200) | BoldFm},
+		{0, "38:5:100", Color(0).Index(100)},
+		{0, "38;2;255;0;0", Color(0).Index(9)},
+		{0, "48:2::0:0:255", Color(0).BgIndex(21)},
+		{0, "38;5", 0},        // incomplete
+		{0, "38;9;1", BoldFm}, // unknown
+		{BoldFm, "1000", BoldFm},
+		{UnderlineFm, "4:3", CurlyUnderlineFm},
+		{0, "4:4", DottedUnderlineFm},
+		{DoublyUnderlineFm, "4:5", DashedUnderlineFm},
+		{CurlyUnderlineFm, "4:1", UnderlineFm},
+		{CurlyUnderlineFm, "4:2", DoublyUnderlineFm},
+		{CurlyUnderlineFm | RedFg, "4:0", RedFg},
+		{DashedUnderlineFm, "24", 0},
+		{0, "58;5;100", Color(0).UnderlineIndex(100)},
+		{0, "58:5:100", Color(0).UnderlineIndex(100)},
+		{0, "58:2::255:0:0", Color(0).UnderlineIndex(9)},
+		{Color(0).UnderlineIndex(100) | RedFg, "59", RedFg},
+	} {
+		assert.Equal(t, tt.want, tt.c.ApplySGR(tt.params), "%q", tt.params)
+	}
+}
+
+func Test_sgrParams(t *testing.T) {
+	for seq, want := range map[string]string{
+		"\033[m":          "",
+		"\033[0;1;31m":    "0;1;31",
+		"\033[38:5:100m":  "38:5:100",
+		"\033[?2026h":     "!",
+		"\033[?25m":       "!",
+		"\033]8;;x\033\\": "!",
+		"\033[2K":         "!",
+	} {
+		var params, ok = sgrParams(seq)
+		if want == "!" {
+			assert.False(t, ok, "%q", seq)
+			continue
+		}
+		assert.True(t, ok, "%q", seq)
+		assert.Equal(t, want, params, "%q", seq)
+	}
+}
+
+func Test_oscLink(t *testing.T) {
+	var target, params, ok = oscLink("\033]8;;http://x/\033\\")
+	assert.True(t, ok)
+	assert.Equal(t, "http://x/", target)
+	assert.Nil(t, params)
+	target, params, ok = oscLink("\033]8;id=1:a=b;http://x/\a")
+	assert.True(t, ok)
+	assert.Equal(t, "http://x/", target)
+	assert.Equal(t, []HyperlinkParam{{"id", "1"}, {"a", "b"}}, params)
+	target, _, ok = oscLink("\033]8;;\033\\")
+	assert.True(t, ok)
+	assert.Equal(t, "", target)
+	for _, seq := range []string{
+		"\033[31m",
+		"\033]0;title\a",
+		"\033]8;;http://x/",
+		"\033]8;http://x/\a",
+	} {
+		_, _, ok = oscLink(seq)
+		assert.False(t, ok, "%q", seq)
+	}
+}
+
+func TestSpans(t *testing.T) {
+	var au = New()
+	assert.Nil(t, Spans(""))
+	assert.Equal(t, []Span{{Text: "plain"}}, Spans("plain"))
+	assert.Equal(t, []Span{
+		{Text: "we've got ", Color: BlueFg},
+		{Text: "5", Color: CyanFg},
+		{Text: " cats", Color: BlueFg},
+	}, Spans(au.Sprintf(au.Blue("we've got %d cats"), au.Cyan(5))))
+	// the same look, different sequences
+	assert.Equal(t,
+		Spans("\033[1m\033[31mab\033[0m"),
+		Spans("\033[1;31ma\033[0m\033[31;1mb\033[m\033[2K"))
+	assert.Equal(t, []Span{
+		{Text: "see "},
+		{Text: "docs", Color: RedFg, Link: "http://x/",
+			Params: []HyperlinkParam{HyperlinkID("1")}},
+		{Text: "!"},
+	}, Spans("see "+au.Hyperlink(au.Red("docs"), "http://x/",
+		HyperlinkID("1")).String()+"!"))
+}
@@ -37,13 +37,14 @@ package aurora
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"unicode/utf8"
 )
 
 type tailedValue struct {
 	Value
-	tail Color
+	tail Value // the format
 }
 
 func (v *tailedValue) Format(s fmt.State, verb rune) {
@@ -72,11 +73,19 @@ func (v *tailedValue) Format(s fmt.State, verb rune) {
 	var (
 		format = make([]byte, 0, 128)
 		color  = v.Color()
+		tail   = v.tail.Color()
+		// transitions are for 8-bit colors, use full sequences instead
+		full = v.isTrueColor() || v.tail.isTrueColor()
 	)
 	if color != 0 {
-		format = append(format, esc...)
-		format = color.appendNos(format, v.tail != 0)
-		format = append(format, 'm')
+		switch {
+		case tail == 0:
+			format = v.appendSequence(format, color, false)
+		case full:
+			format = v.appendSequence(format, color, true)
+		default:
+			format = append(format, tail.Transition(color)...)
+		}
 	}
 	format = append(format, '%')
 	var f byte
@@ -100,31 +109,50 @@ func (v *tailedValue) Format(s fmt.State, verb rune) {
 		format = append(format, byte(verb))
 	}
 	if color != 0 {
-		if v.tail != 0 {
-			// set next (previous) format clearing current one
-			format = append(format, esc...)
-			format = v.tail.appendNos(format, true)
-			format = append(format, 'm')
-		} else {
+		switch {
+		case tail == 0:
 			format = append(format, clear...) // just clear
+		case full:
+			// set next (previous) format back
+			format = v.tail.appendSequence(format, tail, true)
+		default:
+			format = append(format, color.Transition(tail)...)
 		}
 	}
 	fmt.Fprintf(s, string(format), v.Value.Value())
 }
 
+// tail given Values of the args by given Value format
+func tailArgs(tail Value, args []interface{}) {
+	for i, v := range args {
+		if val, ok := v.(Value); ok {
+			args[i] = &tailedValue{Value: val, tail: tail}
+		}
+	}
+}
+
 func sprintf(format interface{}, args ...interface{}) string {
 	switch ft := format.(type) {
 	case string:
 		return fmt.Sprintf(ft, args...)
 	case Value:
-		for i, v := range args {
-			if val, ok := v.(Value); ok {
-				args[i] = &tailedValue{Value: val, tail: ft.Color()}
-				continue
-			}
-		}
+		tailArgs(ft, args)
 		return fmt.Sprintf(ft.String(), args...)
 	}
 	// unknown type of format (we hope it's a string)
 	return fmt.Sprintf(fmt.Sprint(format), args...)
 }
+
+func fprintf(w io.Writer, format interface{}, args ...interface{}) (
+	n int, err error) {
+
+	switch ft := format.(type) {
+	case string:
+		return fmt.Fprintf(w, ft, args...)
+	case Value:
+		tailArgs(ft, args)
+		return fmt.Fprintf(w, ft.String(), args...)
+	}
+	// unknown type of format (we hope it's a string)
+	return fmt.Fprintf(w, fmt.Sprint(format), args...)
+}
@@ -36,6 +36,7 @@
 package aurora
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -55,8 +56,8 @@ func Test_Sprintf(t *testing.T) {
 	assert.Equal(t, want, got)
 
 	// %s
-	want = `[31mquoted: [0;34m    "blue"[0;31m` +
-		`, [0;32m     green[0;31m[0m`
+	want = `[31mquoted: [34m    "blue"[31m` +
+		`, [32m     green[31m[0m`
 	got = Sprintf(Red("quoted: % 10q, % 10s"), Blue("blue"), Green("green"))
 	assert.Equal(t, want, got)
 
@@ -71,12 +72,12 @@ func Test_Sprintf(t *testing.T) {
 	assert.Equal(t, want, got)
 
 	// precision
-	want = `[31mvalue: [0;34m2.78[0;31m[0m`
+	want = `[31mvalue: [34m2.78[31m[0m`
 	got = Sprintf(Red("value: %1.2f"), Blue(2.7834))
 	assert.Equal(t, want, got)
 
 	// wide verb
-	want = `[31m[0;34m%!世(float64=+2.78)[0;31m[0m`
+	want = `[31m[34m%!世(float64=+2.78)[31m[0m`
 	got = Sprintf(Red("%+1.3世"), Blue(2.7834))
 	assert.Equal(t, want, got)
 
@@ -86,3 +87,65 @@ func Test_Sprintf(t *testing.T) {
 	got = au.Sprintf(Red("%+1.3f"), Blue(2.7834))
 	assert.Equal(t, want, got)
 }
+
+func Test_Fprintf(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		n   int
+		err error
+	)
+
+	n, err = Fprintf(&buf, Red("value: %1.2f"), Blue(2.7834))
+	assert.NoError(t, err)
+	assert.Equal(t, "\033[31mvalue: \033[34m2.78\033[31m\033[0m", buf.String())
+	assert.Equal(t, buf.Len(), n)
+
+	buf.Reset()
+	_, err = Fprintf(&buf, "quoted: %s, %s", Blue("blue"), Green("green"))
+	assert.NoError(t, err)
+	assert.Equal(t, "quoted: \033[34mblue\033[0m, \033[32mgreen\033[0m", buf.String())
+
+	buf.Reset()
+	_, err = Fprintf(&buf, noString("delta: +%d"), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "delta: +3", buf.String())
+
+	// decolor
+	buf.Reset()
+	var au = New(WithColors(false), WithHyperlinks(false))
+	_, err = au.Fprintf(&buf, Red("%+1.3f"), Blue(2.7834))
+	assert.NoError(t, err)
+	assert.Equal(t, `+2.783`, buf.String())
+}
+
+func Test_Fprint(t *testing.T) {
+	var buf bytes.Buffer
+	var _, err = Fprint(&buf, Red("x"), " ", 1, Blue("y"))
+	assert.NoError(t, err)
+	assert.Equal(t, "\033[31mx\033[0m 1 \033[34my\033[0m", buf.String())
+
+	buf.Reset()
+	_, err = Fprintln(&buf, Red("x"), Blue("y"))
+	assert.NoError(t, err)
+	assert.Equal(t, "\033[31mx\033[0m \033[34my\033[0m\n", buf.String())
+
+	// decolor
+	var au = New(WithColors(false), WithHyperlinks(false))
+	buf.Reset()
+	_, err = au.Fprint(&buf, Red("x"), Blue("y"))
+	assert.NoError(t, err)
+	assert.Equal(t, "x y", buf.String())
+
+	buf.Reset()
+	_, err = au.Fprintln(&buf, Red("x"), Blue("y"))
+	assert.NoError(t, err)
+	assert.Equal(t, "x y\n", buf.String())
+}
+
+func Test_Sprint(t *testing.T) {
+	assert.Equal(t, "\033[31mx\033[0m 1", Sprint(Red("x"), " ", 1))
+	assert.Equal(t, "\033[31mx\033[0m 1\n", Sprintln(Red("x"), 1))
+	var au = New(WithColors(false), WithHyperlinks(false))
+	assert.Equal(t, "x y", au.Sprint(Red("x"), Blue("y")))
+	assert.Equal(t, "x y\n", au.Sprintln(Red("x"), Blue("y")))
+}
@@ -0,0 +1,862 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HyperlinkPlaceholder is placeholder of a Style hyperlink template. It's
+// replaced with escaped argument the Style applied to.
+const HyperlinkPlaceholder = "{}"
+
+// prefix of hyperlink template in text representation of a Style
+const styleLinkPrefix = "link="
+
+// A Style is reusable set of colors and formats with optional hyperlink
+// template and output options. A Style can be declared once
+//
+//	var ErrStyle = aurora.NewStyle().Red().Bold()
+//
+// and used many times
+//
+//	fmt.Println(ErrStyle.Apply("error"))
+//
+// A Style is immutable, all its methods return changed copy. By default a
+// Style uses configurations of the Default colorizer. Use the WithOptions or
+// the WithColorizer to change it.
+type Style struct {
+	color Color       // colors and formats
+	rgb   *trueColors // 24-bit colors, if any
+	link  *hyperlink  // hyperlink template
+	au    *Aurora     // colorizer, nil for the Default one
+}
+
+// NewStyle returns new empty Style.
+func NewStyle() Style {
+	return Style{}
+}
+
+// Inherit returns copy of the Style. The copy can be changed without
+// affecting the original Style. For example
+//
+//	var (
+//		ErrStyle       = aurora.NewStyle().Red().Bold()
+//		ErrDetailStyle = ErrStyle.Inherit().Underline()
+//	)
+func (s Style) Inherit() Style {
+	return s
+}
+
+// Color of the Style.
+func (s Style) Color() Color {
+	return s.color
+}
+
+// WithOptions returns Style with own output options. The options are
+// applied over configurations of the colorizer of the Style.
+func (s Style) WithOptions(opts ...Option) Style {
+	s.au = s.colorizer().With(opts...)
+	return s
+}
+
+// WithColorizer returns Style that uses configurations of given colorizer.
+// The nil means the Default colorizer.
+func (s Style) WithColorizer(a *Aurora) Style {
+	s.au = a
+	return s
+}
+
+func (s Style) colorizer() *Aurora {
+	if s.au == nil {
+		return Default()
+	}
+	return s.au
+}
+
+// Apply the Style to given argument. If the argument is a Value, then its
+// colors and formats replaced with the Style ones. If the Style has a
+// hyperlink template, then the argument is wrapped with the hyperlink.
+func (s Style) Apply(arg interface{}) Value {
+	return s.apply(s.colorizer().load().cc, arg)
+}
+
+func (s Style) apply(cc colorConfig, arg interface{}) (val Value) {
+	if v, ok := transform(cc, arg); ok {
+		val = v.Colorize(s.color)
+	} else {
+		val = Value{cc: cc | colorConfig(s.color), value: arg}
+	}
+	val.rgb = s.rgb
+	if !s.link.isExists() {
+		return
+	}
+	return val.Hyperlink(s.HyperlinkTargetFor(val.Value()), s.link.params...)
+}
+
+// HyperlinkTargetFor returns hyperlink target template of the Style, where
+// every HyperlinkPlaceholder replaced with given argument, as the Apply
+// does. Escape sequences of the argument are stripped, and the rest is
+// URL-encoded, including spaces. It returns empty string if the Style has
+// no hyperlink.
+func (s Style) HyperlinkTargetFor(arg interface{}) string {
+	if !s.link.isExists() {
+		return ""
+	}
+	var escaped = strings.ReplaceAll(HyperlinkEscape(Strip(fmt.Sprint(arg))),
+		" ", "%20")
+	return strings.ReplaceAll(s.link.target, HyperlinkPlaceholder, escaped)
+}
+
+// Sprintf is like the Sprintf of the colorizer of the Style, where
+// the Style applied to the format.
+func (s Style) Sprintf(format interface{}, args ...interface{}) string {
+	var cc = s.colorizer().load().cc
+	transformArgs(cc, args)
+	return sprintf(s.apply(cc, format), args...)
+}
+
+// Sprint formats given arguments using fmt.Sprint and applies the Style
+// to the result. The Style is restored after every Value of the arguments,
+// as the Sprintf does.
+func (s Style) Sprint(args ...interface{}) string {
+	var cc = s.colorizer().load().cc
+	transformArgs(cc, args)
+	tailArgs(Value{cc: cc | colorConfig(s.color), rgb: s.rgb}, args)
+	return s.apply(cc, fmt.Sprint(args...)).String()
+}
+
+// String returns text representation of the Style. See MarshalText.
+func (s Style) String() string {
+	var text, _ = s.MarshalText()
+	return string(text)
+}
+
+// MarshalText implements encoding.TextMarshaler interface. It returns
+// human readable representation of colors, formats and hyperlink template
+// of the Style, for example
+//
+//	bold underline red on bright-blue link=https://example.com/{}
+//
+// Hyperlink parameters and output options are not represented.
+func (s Style) MarshalText() (text []byte, err error) {
+	text = appendColorString(text, s.color, s.rgb)
+	if s.link.isExists() {
+		if len(text) > 0 {
+			text = append(text, ' ')
+		}
+		text = append(text, styleLinkPrefix...)
+		text = append(text, s.link.target...)
+	}
+	return
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface. It parses
+// text representation of a Style, replacing colors, formats and hyperlink
+// template of the Style.
+func (s *Style) UnmarshalText(text []byte) (err error) {
+	var (
+		colors []string
+		link   *hyperlink
+	)
+	for _, token := range splitStyle(string(text)) {
+		if !strings.HasPrefix(token, styleLinkPrefix) {
+			colors = append(colors, token)
+			continue
+		}
+		if link != nil {
+			return fmt.Errorf("second hyperlink %q", token)
+		}
+		link = &hyperlink{target: token[len(styleLinkPrefix):]}
+	}
+	var (
+		color Color
+		rgb   *trueColors
+	)
+	if color, rgb, err = parseColors(strings.Join(colors, " ")); err != nil {
+		return
+	}
+	s.color, s.rgb, s.link = color, rgb, link
+	return
+}
+
+// ParseStyle parses text representation of a Style, like
+//
+//	bold italic #ff8800 on blue link=https://example.com/{}
+//
+// See ParseColor for syntax of colors and formats.
+func ParseStyle(text string) (s Style, err error) {
+	err = s.UnmarshalText([]byte(text))
+	return
+}
+
+// Reset colors, formats and hyperlink template.
+func (s Style) Reset() Style {
+	s.color, s.rgb, s.link = 0, nil, nil
+	return s
+}
+
+// Clear colors and formats, preserving hyperlink template.
+func (s Style) Clear() Style {
+	s.color, s.rgb = 0, nil
+	return s
+}
+
+// Colorize replaces colors and formats of the Style with given.
+func (s Style) Colorize(color Color) Style {
+	s.color, s.rgb = color, nil
+	return s
+}
+
+// Merge returns the Style with colors and formats of given Style applied
+// over, like the Color.Merge does, including 24-bit colors. Hyperlink
+// template of given Style, if any, replaces the Style one.
+func (s Style) Merge(over Style) Style {
+	s.rgb = s.rgb.merge(over.rgb, over.color)
+	s.color = s.color.Merge(over.color)
+	if over.link.isExists() {
+		s.link = over.link
+	}
+	return s
+}
+
+// Hyperlink template with given target and parameters. Every
+// HyperlinkPlaceholder of the target replaced with escaped argument
+// the Style applied to. For example
+//
+//	var IssueStyle = aurora.NewStyle().Blue().
+//		Hyperlink("https://example.com/issues/{}")
+//
+//	IssueStyle.Apply(42) // links to https://example.com/issues/42
+//
+// Successive calls replace previously set target and parameters.
+func (s Style) Hyperlink(target string, params ...HyperlinkParam) Style {
+	s.link = &hyperlink{
+		target: target,
+		params: params,
+	}
+	return s
+}
+
+// HyperlinkTarget template if any.
+func (s Style) HyperlinkTarget() (target string) {
+	if s.link != nil {
+		return s.link.target
+	}
+	return // nothing
+}
+
+// HyperlinkParams if any.
+func (s Style) HyperlinkParams() (params []HyperlinkParam) {
+	if s.link != nil {
+		return s.link.params
+	}
+	return // nil
+}
+
+// Formats
+//
+// Bold or increased intensity (1).
+func (s Style) Bold() Style {
+	s.color = s.color.Bold()
+	return s
+}
+
+// Faint, decreased intensity, reset the Bold (2).
+func (s Style) Faint() Style {
+	s.color = s.color.Faint()
+	return s
+}
+
+// DoublyUnderline or Bold off, double-underline per ECMA-48 (21). It depends.
+func (s Style) DoublyUnderline() Style {
+	s.color = s.color.DoublyUnderline()
+	return s
+}
+
+// Fraktur, rarely supported (20).
+func (s Style) Fraktur() Style {
+	s.color = s.color.Fraktur()
+	return s
+}
+
+// Italic, not widely supported, sometimes treated as inverse (3).
+func (s Style) Italic() Style {
+	s.color = s.color.Italic()
+	return s
+}
+
+// Underline (4).
+func (s Style) Underline() Style {
+	s.color = s.color.Underline()
+	return s
+}
+
+// SlowBlink, blinking less than 150 per minute (5).
+func (s Style) SlowBlink() Style {
+	s.color = s.color.SlowBlink()
+	return s
+}
+
+// RapidBlink, blinking 150+ per minute, not widely supported (6).
+func (s Style) RapidBlink() Style {
+	s.color = s.color.RapidBlink()
+	return s
+}
+
+// Blink is alias for the SlowBlink.
+func (s Style) Blink() Style {
+	s.color = s.color.Blink()
+	return s
+}
+
+// Reverse video, swap foreground and background colors (7).
+func (s Style) Reverse() Style {
+	s.color = s.color.Reverse()
+	return s
+}
+
+// Inverse is alias for the Reverse.
+func (s Style) Inverse() Style {
+	s.color = s.color.Inverse()
+	return s
+}
+
+// Conceal, hidden, not widely supported (8).
+func (s Style) Conceal() Style {
+	s.color = s.color.Conceal()
+	return s
+}
+
+// Hidden is alias for the Conceal.
+func (s Style) Hidden() Style {
+	s.color = s.color.Hidden()
+	return s
+}
+
+// CrossedOut, characters legible, but marked for deletion (9).
+func (s Style) CrossedOut() Style {
+	s.color = s.color.CrossedOut()
+	return s
+}
+
+// StrikeThrough is alias for the CrossedOut.
+func (s Style) StrikeThrough() Style {
+	s.color = s.color.StrikeThrough()
+	return s
+}
+
+// Framed (51).
+func (s Style) Framed() Style {
+	s.color = s.color.Framed()
+	return s
+}
+
+// Encircled (52).
+func (s Style) Encircled() Style {
+	s.color = s.color.Encircled()
+	return s
+}
+
+// Overlined (53).
+func (s Style) Overlined() Style {
+	s.color = s.color.Overlined()
+	return s
+}
+
+// Curly underline, not widely supported (4:3).
+func (s Style) CurlyUnderline() Style {
+	s.color = s.color.CurlyUnderline()
+	return s
+}
+
+// Dotted underline, not widely supported (4:4).
+func (s Style) DottedUnderline() Style {
+	s.color = s.color.DottedUnderline()
+	return s
+}
+
+// Dashed underline, not widely supported (4:5).
+func (s Style) DashedUnderline() Style {
+	s.color = s.color.DashedUnderline()
+	return s
+}
+
+// Proportional spacing, rarely supported (26).
+func (s Style) ProportionalSpacing() Style {
+	s.color = s.color.ProportionalSpacing()
+	return s
+}
+
+// IdeogramUnderline or right side line, rarely supported (60).
+func (s Style) IdeogramUnderline() Style {
+	s.color = s.color.IdeogramUnderline()
+	return s
+}
+
+// IdeogramDoubleUnderline or double line on the right side, rarely
+// supported (61).
+func (s Style) IdeogramDoubleUnderline() Style {
+	s.color = s.color.IdeogramDoubleUnderline()
+	return s
+}
+
+// IdeogramOverline or left side line, rarely supported (62).
+func (s Style) IdeogramOverline() Style {
+	s.color = s.color.IdeogramOverline()
+	return s
+}
+
+// IdeogramDoubleOverline or double line on the left side, rarely
+// supported (63).
+func (s Style) IdeogramDoubleOverline() Style {
+	s.color = s.color.IdeogramDoubleOverline()
+	return s
+}
+
+// IdeogramStress marking, rarely supported (64).
+func (s Style) IdeogramStress() Style {
+	s.color = s.color.IdeogramStress()
+	return s
+}
+
+// Superscript, reset the Subscript, rarely supported (73).
+func (s Style) Superscript() Style {
+	s.color = s.color.Superscript()
+	return s
+}
+
+// Subscript, reset the Superscript, rarely supported (74).
+func (s Style) Subscript() Style {
+	s.color = s.color.Subscript()
+	return s
+}
+
+// Font sets alternative font from 1 to 9 (11-19), rarely supported. The
+// zero is the primary font (10).
+func (s Style) Font(n FontIndex) Style {
+	s.color = s.color.Font(n)
+	return s
+}
+
+// Foreground colors.
+//
+// Black foreground color (30).
+func (s Style) Black() Style {
+	s.color = s.color.Black()
+	return s
+}
+
+// Red foreground color (31).
+func (s Style) Red() Style {
+	s.color = s.color.Red()
+	return s
+}
+
+// Green foreground color (32).
+func (s Style) Green() Style {
+	s.color = s.color.Green()
+	return s
+}
+
+// Yellow foreground color (33).
+func (s Style) Yellow() Style {
+	s.color = s.color.Yellow()
+	return s
+}
+
+// Blue foreground color (34).
+func (s Style) Blue() Style {
+	s.color = s.color.Blue()
+	return s
+}
+
+// Magenta foreground color (35).
+func (s Style) Magenta() Style {
+	s.color = s.color.Magenta()
+	return s
+}
+
+// Cyan foreground color (36).
+func (s Style) Cyan() Style {
+	s.color = s.color.Cyan()
+	return s
+}
+
+// White foreground color (37).
+func (s Style) White() Style {
+	s.color = s.color.White()
+	return s
+}
+
+// Bright foreground colors.
+//
+// BrightBlack foreground color (90).
+func (s Style) BrightBlack() Style {
+	s.color = s.color.BrightBlack()
+	return s
+}
+
+// BrightRed foreground color (91).
+func (s Style) BrightRed() Style {
+	s.color = s.color.BrightRed()
+	return s
+}
+
+// BrightGreen foreground color (92).
+func (s Style) BrightGreen() Style {
+	s.color = s.color.BrightGreen()
+	return s
+}
+
+// BrightYellow foreground color (93).
+func (s Style) BrightYellow() Style {
+	s.color = s.color.BrightYellow()
+	return s
+}
+
+// BrightBlue foreground color (94).
+func (s Style) BrightBlue() Style {
+	s.color = s.color.BrightBlue()
+	return s
+}
+
+// BrightMagenta foreground color (95).
+func (s Style) BrightMagenta() Style {
+	s.color = s.color.BrightMagenta()
+	return s
+}
+
+// BrightCyan foreground color (96).
+func (s Style) BrightCyan() Style {
+	s.color = s.color.BrightCyan()
+	return s
+}
+
+// BrightWhite foreground color (97).
+func (s Style) BrightWhite() Style {
+	s.color = s.color.BrightWhite()
+	return s
+}
+
+// Other colors.
+//
+// Index of pre-defined 8-bit foreground color from 0 to 255 (38;5;n).
+//
+//	  0-  7:  standard colors (as in ESC [ 30–37 m)
+//	  8- 15:  high intensity colors (as in ESC [ 90–97 m)
+//	 16-231:  6 × 6 × 6 cube (216 colors): 16 + 36 × r + 6 × g + b (0 ≤ r, g, b ≤ 5)
+//	232-255:  grayscale from black to white in 24 steps
+func (s Style) Index(n ColorIndex) Style {
+	s.color, s.rgb = s.color.Index(n), s.rgb.noFg()
+	return s
+}
+
+// TrueColor sets 24-bit foreground color (38;2;r;g;b), used with the
+// ProfileTrueColor. Other profiles use nearest 8-bit color, see RGB.Index.
+func (s Style) TrueColor(rgb RGB) Style {
+	s.color, s.rgb = s.color.TrueColor(rgb), s.rgb.withFg(rgb)
+	return s
+}
+
+// Gray from 0 to 24.
+func (s Style) Gray(n GrayIndex) Style {
+	s.color, s.rgb = s.color.Gray(n), s.rgb.noFg()
+	return s
+}
+
+// Background colors
+//
+// BgBlack background color (40).
+func (s Style) BgBlack() Style {
+	s.color = s.color.BgBlack()
+	return s
+}
+
+// BgRed background color (41).
+func (s Style) BgRed() Style {
+	s.color = s.color.BgRed()
+	return s
+}
+
+// BgGreen background color (42).
+func (s Style) BgGreen() Style {
+	s.color = s.color.BgGreen()
+	return s
+}
+
+// BgYellow background color (43).
+func (s Style) BgYellow() Style {
+	s.color = s.color.BgYellow()
+	return s
+}
+
+// BgBlue background color (44).
+func (s Style) BgBlue() Style {
+	s.color = s.color.BgBlue()
+	return s
+}
+
+// BgMagenta background color (45).
+func (s Style) BgMagenta() Style {
+	s.color = s.color.BgMagenta()
+	return s
+}
+
+// BgCyan background color (46).
+func (s Style) BgCyan() Style {
+	s.color = s.color.BgCyan()
+	return s
+}
+
+// BgWhite background color (47).
+func (s Style) BgWhite() Style {
+	s.color = s.color.BgWhite()
+	return s
+}
+
+// Bright background colors.
+//
+// BgBrightBlack background color (100).
+func (s Style) BgBrightBlack() Style {
+	s.color = s.color.BgBrightBlack()
+	return s
+}
+
+// BgBrightRed background color (101).
+func (s Style) BgBrightRed() Style {
+	s.color = s.color.BgBrightRed()
+	return s
+}
+
+// BgBrightGreen background color (102).
+func (s Style) BgBrightGreen() Style {
+	s.color = s.color.BgBrightGreen()
+	return s
+}
+
+// BgBrightYellow background color (103).
+func (s Style) BgBrightYellow() Style {
+	s.color = s.color.BgBrightYellow()
+	return s
+}
+
+// BgBrightBlue background color (104).
+func (s Style) BgBrightBlue() Style {
+	s.color = s.color.BgBrightBlue()
+	return s
+}
+
+// BgBrightMagenta background color (105).
+func (s Style) BgBrightMagenta() Style {
+	s.color = s.color.BgBrightMagenta()
+	return s
+}
+
+// BgBrightCyan background color (106).
+func (s Style) BgBrightCyan() Style {
+	s.color = s.color.BgBrightCyan()
+	return s
+}
+
+// BgBrightWhite background color (107).
+func (s Style) BgBrightWhite() Style {
+	s.color = s.color.BgBrightWhite()
+	return s
+}
+
+// Other background colors.
+//
+// BgIndex of 8-bit pre-defined background color from 0 to 255 (48;5;n).
+//
+//	  0-  7:  standard colors (as in ESC [ 40–47 m)
+//	  8- 15:  high intensity colors (as in ESC [100–107 m)
+//	 16-231:  6 × 6 × 6 cube (216 colors): 16 + 36 × r + 6 × g + b (0 ≤ r, g, b ≤ 5)
+//	232-255:  grayscale from black to white in 24 steps
+func (s Style) BgIndex(n ColorIndex) Style {
+	s.color, s.rgb = s.color.BgIndex(n), s.rgb.noBg()
+	return s
+}
+
+// BgTrueColor sets 24-bit background color (48;2;r;g;b). See TrueColor.
+func (s Style) BgTrueColor(rgb RGB) Style {
+	s.color, s.rgb = s.color.BgTrueColor(rgb), s.rgb.withBg(rgb)
+	return s
+}
+
+// BgGray from 0 to 24.
+func (s Style) BgGray(n GrayIndex) Style {
+	s.color, s.rgb = s.color.BgGray(n), s.rgb.noBg()
+	return s
+}
+
+// Underline color.
+//
+// UnderlineIndex sets underline color, 8-bit pre-defined color from 0 to
+// 255 (58;5;n), not widely supported. See Index for details.
+func (s Style) UnderlineIndex(n ColorIndex) Style {
+	s.color, s.rgb = s.color.UnderlineIndex(n), s.rgb.noUl()
+	return s
+}
+
+// UnderlineGray sets gray underline color from 0 to 23.
+func (s Style) UnderlineGray(n GrayIndex) Style {
+	s.color, s.rgb = s.color.UnderlineGray(n), s.rgb.noUl()
+	return s
+}
+
+// Removal.
+//
+// NoBold removes Bold format.
+func (s Style) NoBold() Style {
+	s.color = s.color.NoBold()
+	return s
+}
+
+// NoFaint removes Faint format.
+func (s Style) NoFaint() Style {
+	s.color = s.color.NoFaint()
+	return s
+}
+
+// NoItalic removes Italic format.
+func (s Style) NoItalic() Style {
+	s.color = s.color.NoItalic()
+	return s
+}
+
+// NoUnderline removes Underline and DoublyUnderline formats.
+func (s Style) NoUnderline() Style {
+	s.color = s.color.NoUnderline()
+	return s
+}
+
+// NoBlink removes SlowBlink and RapidBlink formats.
+func (s Style) NoBlink() Style {
+	s.color = s.color.NoBlink()
+	return s
+}
+
+// NoReverse removes Reverse format.
+func (s Style) NoReverse() Style {
+	s.color = s.color.NoReverse()
+	return s
+}
+
+// NoConceal removes Conceal format.
+func (s Style) NoConceal() Style {
+	s.color = s.color.NoConceal()
+	return s
+}
+
+// NoCrossedOut removes CrossedOut format.
+func (s Style) NoCrossedOut() Style {
+	s.color = s.color.NoCrossedOut()
+	return s
+}
+
+// NoFraktur removes Fraktur format.
+func (s Style) NoFraktur() Style {
+	s.color = s.color.NoFraktur()
+	return s
+}
+
+// NoFramed removes Framed format.
+func (s Style) NoFramed() Style {
+	s.color = s.color.NoFramed()
+	return s
+}
+
+// NoEncircled removes Encircled format.
+func (s Style) NoEncircled() Style {
+	s.color = s.color.NoEncircled()
+	return s
+}
+
+// NoOverlined removes Overlined format.
+func (s Style) NoOverlined() Style {
+	s.color = s.color.NoOverlined()
+	return s
+}
+
+// NoForeground removes foreground color.
+func (s Style) NoForeground() Style {
+	s.color = s.color.NoForeground()
+	return s
+}
+
+// NoBackground removes background color.
+func (s Style) NoBackground() Style {
+	s.color = s.color.NoBackground()
+	return s
+}
+
+// NoUnderlineColor removes underline color.
+func (s Style) NoUnderlineColor() Style {
+	s.color = s.color.NoUnderlineColor()
+	return s
+}
+
+// NoProportionalSpacing removes ProportionalSpacing format.
+func (s Style) NoProportionalSpacing() Style {
+	s.color = s.color.NoProportionalSpacing()
+	return s
+}
+
+// NoIdeograms removes all ideogram formats.
+func (s Style) NoIdeograms() Style {
+	s.color = s.color.NoIdeograms()
+	return s
+}
+
+// NoScript removes Superscript and Subscript formats.
+func (s Style) NoScript() Style {
+	s.color = s.color.NoScript()
+	return s
+}
+
+// NoFont removes alternative font, using the primary one.
+func (s Style) NoFont() Style {
+	s.color = s.color.NoFont()
+	return s
+}
+
+// Without removes formats and colors of given Color. See Color.Without.
+func (s Style) Without(color Color) Style {
+	s.color = s.color.Without(color)
+	return s
+}
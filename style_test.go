@@ -0,0 +1,275 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStyle(t *testing.T) {
+	assert.Zero(t, NewStyle())
+	assert.Zero(t, NewStyle().Color())
+}
+
+func TestStyle_Inherit(t *testing.T) {
+	var (
+		errStyle    = NewStyle().Red().Bold()
+		detailStyle = errStyle.Inherit().Underline()
+	)
+	assert.Equal(t, RedFg|BoldFm, errStyle.Color())
+	assert.Equal(t, RedFg|BoldFm|UnderlineFm, detailStyle.Color())
+}
+
+func TestStyle_Apply(t *testing.T) {
+	var style = NewStyle().Red().Bold()
+	assert.Equal(t, "\033[1;31mx\033[0m", style.Apply("x").String())
+	// replaces colors of a Value
+	assert.Equal(t, "\033[1;31mx\033[0m", style.Apply(BgBlue("x")).String())
+	// own options
+	assert.Equal(t, "x", style.WithOptions(WithColors(false)).Apply("x").
+		String())
+	// colorizer
+	var au = New(WithColors(false))
+	assert.Equal(t, "x", style.WithColorizer(au).Apply(Blue("x")).String())
+	assert.Equal(t, "\033[1;31mx\033[0m",
+		style.WithColorizer(au).WithColorizer(nil).Apply("x").String())
+}
+
+func TestStyle_Hyperlink(t *testing.T) {
+	var style = NewStyle().Blue().
+		Hyperlink("https://example.com/issues/{}", HyperlinkID("1"))
+	assert.Equal(t, "https://example.com/issues/{}", style.HyperlinkTarget())
+	assert.Equal(t, []HyperlinkParam{HyperlinkID("1")},
+		style.HyperlinkParams())
+	var val = style.Apply(42)
+	assert.Equal(t, "https://example.com/issues/42", val.HyperlinkTarget())
+	assert.Equal(t, "\033]8;id=1;https://example.com/issues/42\033\\"+
+		"\033[34m42\033[0m\033]8;;\033\\", val.String())
+	// escaped
+	assert.Equal(t, "https://example.com/issues/%C3%A4",
+		style.Apply("ä").HyperlinkTarget())
+	assert.Equal(t, "https://example.com/issues/a%20b",
+		style.Apply(Red("a b")).HyperlinkTarget())
+	assert.Equal(t, "https://example.com/issues/a%20b%20c",
+		style.HyperlinkTargetFor("a "+Red("b").String()+" c"))
+	assert.Equal(t, "", NewStyle().HyperlinkTargetFor("x"))
+	// clear
+	assert.Equal(t, "https://example.com/issues/{}",
+		style.Clear().HyperlinkTarget())
+	assert.Zero(t, style.Clear().Color())
+	// reset
+	assert.Zero(t, style.Reset())
+	assert.Equal(t, "", style.Reset().HyperlinkTarget())
+	assert.Nil(t, style.Reset().HyperlinkParams())
+	// disabled hyperlinks
+	assert.Equal(t, "\033[34mhttps://example.com/issues/42\033[0m",
+		style.WithOptions(WithHyperlinks(false)).Apply(42).String())
+}
+
+func TestStyle_Sprintf(t *testing.T) {
+	var style = NewStyle().Red()
+	assert.Equal(t, "\033[31mvalue: \033[34m2.78\033[31m\033[0m",
+		style.Sprintf("value: %1.2f", Blue(2.7834)))
+	assert.Equal(t, "\033[31mx 1\033[0m", style.Sprint("x ", 1))
+	// the Style restored after nested values
+	assert.Equal(t, "\033[1ma \033[0;31mb\033[0;1m c\033[0m",
+		NewStyle().Bold().Sprint("a ", Red("b"), " c"))
+	assert.Equal(t, "\033]8;;https://x/a%20b%20c\033\\"+
+		"\033[1ma \033[0;31mb\033[0;1m c\033[0m\033]8;;\033\\",
+		NewStyle().Bold().Hyperlink("https://x/{}").Sprint("a ", Red("b"),
+			" c"))
+	assert.Equal(t, "+2.783", style.WithOptions(WithColors(false)).
+		Sprintf("%+1.3f", Blue(2.7834)))
+}
+
+func TestStyle_colors(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		style Style
+		color Color
+	}{
+		{"Colorize", NewStyle().Colorize(RedFg | BoldFm), RedFg | BoldFm},
+		{"Bold", NewStyle().Bold(), Color(0).Bold()},
+		{"Faint", NewStyle().Faint(), Color(0).Faint()},
+		{"DoublyUnderline", NewStyle().DoublyUnderline(),
+			Color(0).DoublyUnderline()},
+		{"Fraktur", NewStyle().Fraktur(), Color(0).Fraktur()},
+		{"Italic", NewStyle().Italic(), Color(0).Italic()},
+		{"Underline", NewStyle().Underline(), Color(0).Underline()},
+		{"SlowBlink", NewStyle().SlowBlink(), Color(0).SlowBlink()},
+		{"RapidBlink", NewStyle().RapidBlink(), Color(0).RapidBlink()},
+		{"Blink", NewStyle().Blink(), Color(0).Blink()},
+		{"Reverse", NewStyle().Reverse(), Color(0).Reverse()},
+		{"Inverse", NewStyle().Inverse(), Color(0).Inverse()},
+		{"Conceal", NewStyle().Conceal(), Color(0).Conceal()},
+		{"Hidden", NewStyle().Hidden(), Color(0).Hidden()},
+		{"CrossedOut", NewStyle().CrossedOut(), Color(0).CrossedOut()},
+		{"StrikeThrough", NewStyle().StrikeThrough(),
+			Color(0).StrikeThrough()},
+		{"Framed", NewStyle().Framed(), Color(0).Framed()},
+		{"Encircled", NewStyle().Encircled(), Color(0).Encircled()},
+		{"Overlined", NewStyle().Overlined(), Color(0).Overlined()},
+		{"Black", NewStyle().Black(), Color(0).Black()},
+		{"Red", NewStyle().Red(), Color(0).Red()},
+		{"Green", NewStyle().Green(), Color(0).Green()},
+		{"Yellow", NewStyle().Yellow(), Color(0).Yellow()},
+		{"Blue", NewStyle().Blue(), Color(0).Blue()},
+		{"Magenta", NewStyle().Magenta(), Color(0).Magenta()},
+		{"Cyan", NewStyle().Cyan(), Color(0).Cyan()},
+		{"White", NewStyle().White(), Color(0).White()},
+		{"BrightBlack", NewStyle().BrightBlack(), Color(0).BrightBlack()},
+		{"BrightRed", NewStyle().BrightRed(), Color(0).BrightRed()},
+		{"BrightGreen", NewStyle().BrightGreen(), Color(0).BrightGreen()},
+		{"BrightYellow", NewStyle().BrightYellow(), Color(0).BrightYellow()},
+		{"BrightBlue", NewStyle().BrightBlue(), Color(0).BrightBlue()},
+		{"BrightMagenta", NewStyle().BrightMagenta(),
+			Color(0).BrightMagenta()},
+		{"BrightCyan", NewStyle().BrightCyan(), Color(0).BrightCyan()},
+		{"BrightWhite", NewStyle().BrightWhite(), Color(0).BrightWhite()},
+		{"Index", NewStyle().Index(178), Color(0).Index(178)},
+		{"Gray", NewStyle().Gray(14), Color(0).Gray(14)},
+		{"BgBlack", NewStyle().BgBlack(), Color(0).BgBlack()},
+		{"BgRed", NewStyle().BgRed(), Color(0).BgRed()},
+		{"BgGreen", NewStyle().BgGreen(), Color(0).BgGreen()},
+		{"BgYellow", NewStyle().BgYellow(), Color(0).BgYellow()},
+		{"BgBlue", NewStyle().BgBlue(), Color(0).BgBlue()},
+		{"BgMagenta", NewStyle().BgMagenta(), Color(0).BgMagenta()},
+		{"BgCyan", NewStyle().BgCyan(), Color(0).BgCyan()},
+		{"BgWhite", NewStyle().BgWhite(), Color(0).BgWhite()},
+		{"BgBrightBlack", NewStyle().BgBrightBlack(),
+			Color(0).BgBrightBlack()},
+		{"BgBrightRed", NewStyle().BgBrightRed(), Color(0).BgBrightRed()},
+		{"BgBrightGreen", NewStyle().BgBrightGreen(),
+			Color(0).BgBrightGreen()},
+		{"BgBrightYellow", NewStyle().BgBrightYellow(),
+			Color(0).BgBrightYellow()},
+		{"BgBrightBlue", NewStyle().BgBrightBlue(), Color(0).BgBrightBlue()},
+		{"BgBrightMagenta", NewStyle().BgBrightMagenta(),
+			Color(0).BgBrightMagenta()},
+		{"BgBrightCyan", NewStyle().BgBrightCyan(), Color(0).BgBrightCyan()},
+		{"BgBrightWhite", NewStyle().BgBrightWhite(),
+			Color(0).BgBrightWhite()},
+		{"BgIndex", NewStyle().BgIndex(187), Color(0).BgIndex(187)},
+		{"BgGray", NewStyle().BgGray(15), Color(0).BgGray(15)},
+		{"TrueColor", NewStyle().TrueColor(0xff8800), Color(0).Index(208)},
+		{"BgTrueColor", NewStyle().BgTrueColor(0xff8800),
+			Color(0).BgIndex(208)},
+		{"CurlyUnderline", NewStyle().CurlyUnderline(),
+			Color(0).CurlyUnderline()},
+		{"DottedUnderline", NewStyle().DottedUnderline(),
+			Color(0).DottedUnderline()},
+		{"DashedUnderline", NewStyle().DashedUnderline(),
+			Color(0).DashedUnderline()},
+		{"UnderlineIndex", NewStyle().UnderlineIndex(187),
+			Color(0).UnderlineIndex(187)},
+		{"UnderlineGray", NewStyle().UnderlineGray(15),
+			Color(0).UnderlineGray(15)},
+		{"NoUnderlineColor", NewStyle().UnderlineGray(15).NoUnderlineColor(), 0},
+		{"ProportionalSpacing", NewStyle().ProportionalSpacing(),
+			ProportionalSpacingFm},
+		{"IdeogramUnderline", NewStyle().IdeogramUnderline(),
+			IdeogramUnderlineFm},
+		{"IdeogramDoubleUnderline", NewStyle().IdeogramDoubleUnderline(),
+			IdeogramDoubleUnderlineFm},
+		{"IdeogramOverline", NewStyle().IdeogramOverline(), IdeogramOverlineFm},
+		{"IdeogramDoubleOverline", NewStyle().IdeogramDoubleOverline(),
+			IdeogramDoubleOverlineFm},
+		{"IdeogramStress", NewStyle().IdeogramStress(), IdeogramStressFm},
+		{"Superscript", NewStyle().Superscript(), SuperscriptFm},
+		{"Subscript", NewStyle().Subscript(), SubscriptFm},
+		{"Font", NewStyle().Font(4), Color(0).Font(4)},
+		{"NoProportionalSpacing", NewStyle().ProportionalSpacing().
+			NoProportionalSpacing(), 0},
+		{"NoIdeograms", NewStyle().IdeogramStress().NoIdeograms(), 0},
+		{"NoScript", NewStyle().Subscript().NoScript(), 0},
+		{"NoFont", NewStyle().Font(4).NoFont(), 0},
+		{"NoBold", NewStyle().Bold().Italic().NoBold(), ItalicFm},
+		{"NoFaint", NewStyle().Faint().NoFaint(), 0},
+		{"NoItalic", NewStyle().Italic().NoItalic(), 0},
+		{"NoUnderline", NewStyle().DoublyUnderline().NoUnderline(), 0},
+		{"NoBlink", NewStyle().RapidBlink().NoBlink(), 0},
+		{"NoReverse", NewStyle().Reverse().NoReverse(), 0},
+		{"NoConceal", NewStyle().Conceal().NoConceal(), 0},
+		{"NoCrossedOut", NewStyle().CrossedOut().NoCrossedOut(), 0},
+		{"NoFraktur", NewStyle().Fraktur().NoFraktur(), 0},
+		{"NoFramed", NewStyle().Framed().NoFramed(), 0},
+		{"NoEncircled", NewStyle().Encircled().NoEncircled(), 0},
+		{"NoOverlined", NewStyle().Overlined().NoOverlined(), 0},
+		{"NoForeground", NewStyle().Red().BgRed().NoForeground(), RedBg},
+		{"NoBackground", NewStyle().Red().BgRed().NoBackground(), RedFg},
+		{"Without", NewStyle().Bold().Red().BgRed().Without(BoldFm | BlackBg),
+			RedFg},
+	} {
+		assert.Equal(t, tt.color, tt.style.Color(), tt.name)
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	var s, err = ParseStyle("bold italic #ff8800 on rgb(0, 0, 255) " +
+		"link=https://example.com/{}")
+	require.NoError(t, err)
+	assert.Equal(t, NewStyle().Bold().Italic().TrueColor(0xff8800).
+		BgTrueColor(0x0000ff).Hyperlink("https://example.com/{}"), s)
+	assert.Equal(t, BoldFm|ItalicFm|Color(0).Index(208).BgIndex(21),
+		s.Color())
+	assert.Equal(t, "bold italic #ff8800 on #0000ff "+
+		"link=https://example.com/{}", s.String())
+	_, err = ParseStyle("bold pinkish")
+	assert.EqualError(t, err, `unknown color or format "pinkish"`)
+}
+
+func TestStyle_MarshalText(t *testing.T) {
+	var style = NewStyle().Bold().Red().BgBrightBlue().
+		Hyperlink("https://example.com/{}")
+	var text, err = style.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "bold red on bright-blue link=https://example.com/{}",
+		string(text))
+	assert.Equal(t, string(text), style.String())
+	assert.Equal(t, "", NewStyle().String())
+	assert.Equal(t, "link=x", NewStyle().Hyperlink("x").String())
+	// round trip
+	var got Style
+	require.NoError(t, got.UnmarshalText(text))
+	assert.Equal(t, style, got)
+	// errors
+	assert.Error(t, got.UnmarshalText([]byte("link=x link=y")))
+	assert.Error(t, got.UnmarshalText([]byte("bold pinkish")))
+	assert.Equal(t, style, got, "changed on error")
+}
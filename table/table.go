@@ -0,0 +1,412 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+// Package table renders tables with colored cells, rows and columns.
+//
+//	var t = table.New("NAME", "STATUS", "AGE")
+//	t.Append("web", aurora.Green("Running"), "1d")
+//	t.Append("worker", aurora.Red("Failed"), "12h")
+//	t.WriteTo(os.Stdout)
+//
+// Cells can be any values, including aurora Values with colors and
+// hyperlinks. Styles of columns, rows and cells are merged in that order,
+// and colors of a Value override them. A cell can have many lines, and a row
+// is as high as its highest cell. The Table uses configurations of its
+// colorizer. If colors are disabled, then the Table is rendered without
+// escape sequences using ASCII borders.
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+// Align of a column.
+type Align uint8
+
+// Available alignments.
+const (
+	AlignLeft   Align = iota // left, default
+	AlignRight               // right
+	AlignCenter              // center
+)
+
+// A Border of a table.
+type Border struct {
+	Horizontal string // horizontal line
+	Vertical   string // vertical line
+
+	TopLeft  string // top left corner
+	TopCross string // top junction
+	TopRight string // top right corner
+
+	Left  string // left junction of the header separator
+	Cross string // cross of the header separator
+	Right string // right junction of the header separator
+
+	BottomLeft  string // bottom left corner
+	BottomCross string // bottom junction
+	BottomRight string // bottom right corner
+
+	Ellipsis string // tail of truncated cells
+}
+
+// Predefined borders.
+var (
+	// BoxBorder drawn with box characters.
+	BoxBorder = Border{
+		Horizontal: "─",
+		Vertical:   "│",
+		TopLeft:    "┌", TopCross: "┬", TopRight: "┐",
+		Left: "├", Cross: "┼", Right: "┤",
+		BottomLeft: "└", BottomCross: "┴", BottomRight: "┘",
+		Ellipsis: "…",
+	}
+	// RoundedBorder is BoxBorder with rounded corners.
+	RoundedBorder = Border{
+		Horizontal: "─",
+		Vertical:   "│",
+		TopLeft:    "╭", TopCross: "┬", TopRight: "╮",
+		Left: "├", Cross: "┼", Right: "┤",
+		BottomLeft: "╰", BottomCross: "┴", BottomRight: "╯",
+		Ellipsis: "…",
+	}
+	// ASCIIBorder drawn with ASCII characters.
+	ASCIIBorder = Border{
+		Horizontal: "-",
+		Vertical:   "|",
+		TopLeft:    "+", TopCross: "+", TopRight: "+",
+		Left: "+", Cross: "+", Right: "+",
+		BottomLeft: "+", BottomCross: "+", BottomRight: "+",
+		Ellipsis: "...",
+	}
+)
+
+// A Column of a table.
+type Column struct {
+	Align    Align        // alignment of cells
+	MaxWidth int          // max display width of cells, 0 is no limit
+	Style    aurora.Style // style of cells, excluding the header
+}
+
+// A Table of values. Fields of the Table can be changed before
+// rendering. The Table is not safe for concurrent use.
+type Table struct {
+	// Colorizer used to render the Table. The nil means the
+	// aurora.Default().
+	Colorizer aurora.Colorizer
+	// Border of the Table. The zero Border means BoxBorder, or the
+	// ASCIIBorder if colors are disabled.
+	Border Border
+	// BorderStyle is style of the Border.
+	BorderStyle aurora.Style
+
+	Header      []interface{} // header, can be empty
+	HeaderStyle aurora.Style  // style of the header, bold by default
+	Rows        [][]interface{}
+	Columns     []Column // columns, can be shorter than rows
+
+	// Zebra style applied to every second row, for example
+	//
+	//	t.Zebra = aurora.NewStyle().BgGray(3)
+	//
+	Zebra aurora.Style
+	// RowStyle, if set, returns style of a row by its index.
+	RowStyle func(row int) aurora.Style
+	// CellStyle, if set, returns style of a cell by its row and column
+	// indices, and value.
+	CellStyle func(row, col int, val interface{}) aurora.Style
+}
+
+// New Table with given header.
+func New(header ...interface{}) (t *Table) {
+	t = new(Table)
+	t.Header = header
+	t.HeaderStyle = aurora.NewStyle().Bold()
+	return
+}
+
+// Append a row to the Table.
+func (t *Table) Append(cells ...interface{}) *Table {
+	t.Rows = append(t.Rows, cells)
+	return t
+}
+
+func (t *Table) colorizer() aurora.Colorizer {
+	if t.Colorizer == nil {
+		return aurora.Default()
+	}
+	return t.Colorizer
+}
+
+// a rendered cell without padding
+type cell struct {
+	lines  []string     // lines of the cell, including hyperlinks
+	widths []int        // display widths of the lines
+	width  int          // max display width of the lines
+	style  aurora.Style // merged style
+}
+
+// renderer of a Table
+type renderer struct {
+	t      *Table
+	au     aurora.Colorizer
+	conf   aurora.Config
+	border Border
+	widths []int
+	buf    bytes.Buffer
+}
+
+func (t *Table) renderer() (r *renderer) {
+	r = &renderer{t: t, au: t.colorizer()}
+	r.conf = r.au.Config()
+	r.border = t.Border
+	if r.border == (Border{}) {
+		if r.conf.Colors {
+			r.border = BoxBorder
+		} else {
+			r.border = ASCIIBorder
+		}
+	}
+	return
+}
+
+func (t *Table) column(col int) (c Column) {
+	if col < len(t.Columns) {
+		c = t.Columns[col]
+	}
+	return
+}
+
+// cell of given row (-1 is the header) and column
+func (r *renderer) cell(row, col int, val interface{}) (c cell) {
+	var (
+		style  aurora.Style
+		target string
+		params []aurora.HyperlinkParam
+	)
+	if row < 0 {
+		style = r.t.HeaderStyle
+	} else {
+		style = r.t.column(col).Style
+		if row%2 == 1 {
+			style = style.Merge(r.t.Zebra)
+		}
+		if r.t.RowStyle != nil {
+			style = style.Merge(r.t.RowStyle(row))
+		}
+		if r.t.CellStyle != nil {
+			style = style.Merge(r.t.CellStyle(row, col, val))
+		}
+	}
+	c.style = style
+	if v, ok := val.(aurora.Value); ok {
+		c.style = c.style.Merge(v.Style())
+		target, params = v.HyperlinkTarget(), v.HyperlinkParams()
+		val = v.Value()
+	}
+	var text string
+	if val != nil {
+		text = fmt.Sprint(val) // nil is empty cell
+	}
+	if !r.conf.Colors {
+		text = aurora.Strip(text)
+	}
+	if !r.conf.Hyperlinks {
+		target = ""
+	} else if target == "" {
+		target, params = style.HyperlinkTargetFor(text), style.HyperlinkParams()
+	}
+	// every line is linked separately, not to link borders
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if target != "" {
+			line = r.au.Hyperlink(line, target, params...).String()
+		}
+		if max := r.t.column(col).MaxWidth; max > 0 {
+			line = aurora.Truncate(line, max, r.border.Ellipsis)
+		}
+		var width = aurora.DisplayWidth(line)
+		if width > c.width {
+			c.width = width
+		}
+		c.lines = append(c.lines, line)
+		c.widths = append(c.widths, width)
+	}
+	return
+}
+
+// line of the border
+func (r *renderer) line(left, cross, right string) {
+	if r.border.Horizontal == "" {
+		return
+	}
+	var line strings.Builder
+	line.WriteString(left)
+	for i, w := range r.widths {
+		if i > 0 {
+			line.WriteString(cross)
+		}
+		line.WriteString(strings.Repeat(r.border.Horizontal, w+2))
+	}
+	line.WriteString(right)
+	r.buf.WriteString(r.styled(line.String(), r.t.BorderStyle).String())
+	r.buf.WriteByte('\n')
+}
+
+// styled text
+func (r *renderer) styled(text string, style aurora.Style) aurora.Value {
+	return r.au.Colorize(text, style.Color()).Merge(style)
+}
+
+// append vertical border to given segments of a line
+func (r *renderer) vertical(line []aurora.Value) []aurora.Value {
+	if r.border.Vertical == "" {
+		return line
+	}
+	return append(line, r.styled(r.border.Vertical, r.t.BorderStyle))
+}
+
+// row of given cells, as high as its highest cell
+func (r *renderer) row(cells []cell) {
+	var height = 1
+	for _, c := range cells {
+		if len(c.lines) > height {
+			height = len(c.lines)
+		}
+	}
+	for line := 0; line < height; line++ {
+		r.rowLine(cells, line)
+	}
+}
+
+// given line of a row
+func (r *renderer) rowLine(cells []cell, line int) {
+	var segs = r.vertical(nil)
+	for i, w := range r.widths {
+		var (
+			c     cell
+			text  string
+			width int
+		)
+		if i < len(cells) {
+			c = cells[i]
+		}
+		if line < len(c.lines) {
+			text, width = c.lines[line], c.widths[line]
+		}
+		var left, right int
+		switch pad := w - width; r.t.column(i).Align {
+		case AlignRight:
+			left = pad
+		case AlignCenter:
+			left, right = pad/2, pad-pad/2
+		default:
+			right = pad
+		}
+		text = " " + strings.Repeat(" ", left) + text +
+			strings.Repeat(" ", right) + " "
+		segs = append(segs, r.styled(text, c.style))
+		if i < len(r.widths)-1 {
+			segs = r.vertical(segs)
+		}
+	}
+	segs = r.vertical(segs)
+	r.buf.WriteString(aurora.Join(segs...)) // minimal transitions
+	r.buf.WriteByte('\n')
+}
+
+func (r *renderer) render() []byte {
+	var (
+		header []cell
+		rows   = make([][]cell, 0, len(r.t.Rows))
+	)
+	for col, val := range r.t.Header {
+		header = append(header, r.cell(-1, col, val))
+	}
+	for i, row := range r.t.Rows {
+		var cells = make([]cell, 0, len(row))
+		for col, val := range row {
+			cells = append(cells, r.cell(i, col, val))
+		}
+		rows = append(rows, cells)
+	}
+	for _, cells := range append([][]cell{header}, rows...) {
+		for i, c := range cells {
+			if i == len(r.widths) {
+				r.widths = append(r.widths, 0)
+			}
+			if c.width > r.widths[i] {
+				r.widths[i] = c.width
+			}
+		}
+	}
+	if len(r.widths) == 0 {
+		return nil // empty table
+	}
+	r.line(r.border.TopLeft, r.border.TopCross, r.border.TopRight)
+	if len(header) > 0 {
+		r.row(header)
+		if len(rows) > 0 {
+			r.line(r.border.Left, r.border.Cross, r.border.Right)
+		}
+	}
+	for _, cells := range rows {
+		r.row(cells)
+	}
+	r.line(r.border.BottomLeft, r.border.BottomCross, r.border.BottomRight)
+	return r.buf.Bytes()
+}
+
+// Bytes returns rendered Table.
+func (t *Table) Bytes() []byte {
+	return t.renderer().render()
+}
+
+// String returns rendered Table.
+func (t *Table) String() string {
+	return string(t.Bytes())
+}
+
+// WriteTo writes rendered Table to given writer.
+func (t *Table) WriteTo(w io.Writer) (n int64, err error) {
+	var m int
+	m, err = w.Write(t.Bytes())
+	return int64(m), err
+}
@@ -0,0 +1,287 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package table
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+// colors and hyperlinks are enabled regardless of output
+var au = aurora.New()
+
+func TestNew(t *testing.T) {
+	var tb = New("a", "b")
+	assert.Equal(t, []interface{}{"a", "b"}, tb.Header)
+	assert.Equal(t, aurora.BoldFm, tb.HeaderStyle.Color())
+	assert.Zero(t, tb.Rows)
+}
+
+func TestTable_Append(t *testing.T) {
+	var tb = New()
+	assert.True(t, tb == tb.Append(1, 2).Append(3))
+	assert.Equal(t, [][]interface{}{{1, 2}, {3}}, tb.Rows)
+}
+
+func TestTable_String_plain(t *testing.T) {
+	var tb = New("NAME", "STATUS", "AGE")
+	tb.Colorizer = aurora.New(aurora.WithColors(false),
+		aurora.WithHyperlinks(false))
+	tb.Columns = []Column{{}, {}, {Align: AlignRight}}
+	tb.Append("web", au.Green("Running"), "1d")
+	tb.Append("worker-1", au.Red("\033[1mFailed\033[0m"), "12h")
+	tb.Append("db", nil)
+	assert.Equal(t, ""+
+		"+----------+---------+-----+\n"+
+		"| NAME     | STATUS  | AGE |\n"+
+		"+----------+---------+-----+\n"+
+		"| web      | Running |  1d |\n"+
+		"| worker-1 | Failed  | 12h |\n"+
+		"| db       |         |     |\n"+
+		"+----------+---------+-----+\n", tb.String())
+}
+
+func TestTable_String_colored(t *testing.T) {
+	var tb = New("ID", "NAME")
+	tb.Colorizer = aurora.New()
+	tb.HeaderStyle = aurora.Style{}
+	tb.Zebra = aurora.NewStyle().BgGray(2)
+	tb.Append(1, au.Red("one"))
+	tb.Append(2, "two")
+	assert.Equal(t, ""+
+		"┌────┬──────┐\n"+
+		"│ ID │ NAME │\n"+
+		"├────┼──────┤\n"+
+		"│ 1  │\033[31m one  \033[0m│\n"+
+		"│\033[48;5;234m 2  \033[0m│\033[48;5;234m two  \033[0m│\n"+
+		"└────┴──────┘\n", tb.String())
+}
+
+func TestTable_styles(t *testing.T) {
+	var tb = New()
+	tb.Colorizer = aurora.New()
+	tb.Border = Border{Vertical: "|"}
+	tb.Columns = []Column{{Style: aurora.NewStyle().Blue().Bold()}}
+	tb.RowStyle = func(row int) aurora.Style {
+		if row == 1 {
+			return aurora.NewStyle().BgRed()
+		}
+		return aurora.Style{}
+	}
+	tb.CellStyle = func(row, col int, val interface{}) aurora.Style {
+		if val == "x" {
+			return aurora.NewStyle().Italic()
+		}
+		return aurora.Style{}
+	}
+	tb.Append("a", "x")
+	tb.Append("b", au.Green("c"))
+	assert.Equal(t, ""+
+		"|\033[1;34m a \033[0m|\033[3m x \033[0m|\n"+
+		"|\033[1;34;41m b \033[0m|\033[32;41m c \033[0m|\n", tb.String())
+}
+
+func TestTable_transitions(t *testing.T) {
+	var tb = New("ID", "NAME")
+	tb.Colorizer = aurora.New()
+	tb.HeaderStyle = aurora.NewStyle().Bold()
+	tb.BorderStyle = aurora.NewStyle().BrightBlack()
+	tb.Border = Border{Vertical: "|"}
+	tb.Append(1, au.Red("one"))
+	assert.Equal(t, ""+
+		"\033[90m|\033[0;1m ID \033[0;90m|\033[0;1m NAME \033[0;90m|\033[0m\n"+
+		"\033[90m|\033[0m 1  \033[90m|\033[31m one  \033[90m|\033[0m\n",
+		tb.String())
+}
+
+func TestTable_TrueColor(t *testing.T) {
+	var (
+		tc = aurora.New(aurora.WithProfile(aurora.ProfileTrueColor))
+		tb = New("A", "B")
+	)
+	tb.Colorizer = tc
+	tb.Border = Border{Vertical: "|"}
+	tb.HeaderStyle = aurora.NewStyle().TrueColor(0xff8800)
+	tb.Zebra = aurora.NewStyle().BgTrueColor(0x663399)
+	tb.Append(tc.TrueColor(0x0000ff, "x"), "y")
+	tb.Append(au.Red("z"), "w")
+	assert.Equal(t, ""+
+		"|\033[38;2;255;136;0m A \033[0m|\033[38;2;255;136;0m B \033[0m|\n"+
+		"|\033[38;2;0;0;255m x \033[0m| y |\n"+
+		"|\033[31;48;2;102;51;153m z \033[0m|\033[48;2;102;51;153m w \033[0m|\n",
+		tb.String())
+}
+
+func TestTable_MaxWidth(t *testing.T) {
+	var tb = New("name")
+	tb.Colorizer = aurora.New(aurora.WithColors(false))
+	tb.Columns = []Column{{MaxWidth: 6}}
+	tb.Append("long value")
+	tb.Append("short")
+	assert.Equal(t, ""+
+		"+--------+\n"+
+		"| name   |\n"+
+		"+--------+\n"+
+		"| lon... |\n"+
+		"| short  |\n"+
+		"+--------+\n", tb.String())
+	tb.Border = BoxBorder
+	tb.Colorizer = aurora.New()
+	tb.HeaderStyle = aurora.Style{}
+	tb.Rows = [][]interface{}{{au.Red("long value")}}
+	assert.Equal(t, ""+
+		"┌────────┐\n"+
+		"│ name   │\n"+
+		"├────────┤\n"+
+		"│\033[31m long … \033[0m│\n"+
+		"└────────┘\n", tb.String())
+}
+
+func TestTable_hyperlinks(t *testing.T) {
+	var tb = New()
+	tb.Colorizer = aurora.New()
+	tb.Border = Border{}
+	tb.Columns = []Column{
+		{Style: aurora.NewStyle().Hyperlink("http://x/{}")},
+	}
+	tb.Append("a b")
+	tb.Append(au.Hyperlink("c", "http://y/"))
+	assert.Equal(t, ""+
+		"┌─────┐\n"+
+		"│ \033]8;;http://x/a%20b\033\\a b\033]8;;\033\\ │\n"+
+		"│ \033]8;;http://y/\033\\c\033]8;;\033\\   │\n"+
+		"└─────┘\n", tb.String())
+	// colored text of the placeholder
+	tb.Rows = [][]interface{}{{au.Bold("a").String() + "b"}}
+	assert.Equal(t, ""+
+		"┌────┐\n"+
+		"│ \033]8;;http://x/ab\033\\\033[1ma\033[0mb\033]8;;\033\\ │\n"+
+		"└────┘\n", tb.String())
+	// disabled
+	tb.Colorizer = aurora.New(aurora.WithHyperlinks(false))
+	tb.Rows = [][]interface{}{{"a b"}, {au.Hyperlink("c", "http://y/")}}
+	assert.Equal(t, ""+
+		"┌─────┐\n"+
+		"│ a b │\n"+
+		"│ c   │\n"+
+		"└─────┘\n", tb.String())
+}
+
+func TestTable_multiline(t *testing.T) {
+	var tb = New("ID", "TEXT")
+	tb.Colorizer = aurora.New(aurora.WithColors(false))
+	tb.Columns = []Column{{Align: AlignRight}}
+	tb.Append(1, "one\r\ntwo lines")
+	tb.Append("a\nb\nc", "x")
+	assert.Equal(t, ""+
+		"+----+-----------+\n"+
+		"| ID | TEXT      |\n"+
+		"+----+-----------+\n"+
+		"|  1 | one       |\n"+
+		"|    | two lines |\n"+
+		"|  a | x         |\n"+
+		"|  b |           |\n"+
+		"|  c |           |\n"+
+		"+----+-----------+\n", tb.String())
+	// linked and colored lines
+	tb.Colorizer = aurora.New()
+	tb.Border = Border{Vertical: "|"}
+	tb.HeaderStyle = aurora.Style{}
+	tb.Header = nil
+	tb.Columns = []Column{{Style: aurora.NewStyle().Red().Hyperlink("x")}}
+	tb.Rows = [][]interface{}{{"a\nbc"}}
+	assert.Equal(t, ""+
+		"|\033[31m \033]8;;x\033\\a\033]8;;\033\\  \033[0m|\n"+
+		"|\033[31m \033]8;;x\033\\bc\033]8;;\033\\ \033[0m|\n", tb.String())
+}
+
+func TestTable_AlignCenter(t *testing.T) {
+	var tb = New()
+	tb.Colorizer = aurora.New(aurora.WithColors(false))
+	tb.Columns = []Column{{Align: AlignCenter}}
+	tb.Append("a")
+	tb.Append("bbbb")
+	assert.Equal(t, ""+
+		"+------+\n"+
+		"|  a   |\n"+
+		"| bbbb |\n"+
+		"+------+\n", tb.String())
+}
+
+func TestTable_empty(t *testing.T) {
+	assert.Equal(t, "", New().String())
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("test")
+}
+
+func TestTable_WriteTo(t *testing.T) {
+	var tb = New("x")
+	tb.Colorizer = aurora.New(aurora.WithColors(false))
+	var buf bytes.Buffer
+	var n, err = tb.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Equal(t, "+---+\n| x |\n+---+\n", buf.String())
+	_, err = tb.WriteTo(errWriter{})
+	assert.EqualError(t, err, "test")
+}
+
+func Example() {
+	var t = New("NAME", "STATUS", "AGE")
+	t.Colorizer = aurora.New(aurora.WithColors(false))
+	t.Columns = []Column{{}, {}, {Align: AlignRight}}
+	t.Append("web", "Running", "1d")
+	t.Append("worker", "Failed", "12h")
+	t.WriteTo(os.Stdout)
+
+	// Output:
+	// +--------+---------+-----+
+	// | NAME   | STATUS  | AGE |
+	// +--------+---------+-----+
+	// | web    | Running |  1d |
+	// | worker | Failed  | 12h |
+	// +--------+---------+-----+
+}
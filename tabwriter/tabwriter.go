@@ -0,0 +1,597 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// This file is derived from the text/tabwriter package of the Go standard
+// library. Modifications, making it aware of escape sequences and display
+// width, are made by The Aurora Authors and are distributed under the same
+// BSD-style license.
+
+// Package tabwriter implements a write filter (tabwriter.Writer) that
+// translates tabbed columns in input into properly aligned text. It's
+// a drop-in replacement of the text/tabwriter, with the same API and the
+// same behaviour, but it measures cells by their display width. That is,
+// ANSI escape sequences (colors, formats, hyperlinks) take no space, East
+// Asian wide characters take two columns and combining marks take no
+// columns. Thus, columns of colored values are aligned properly.
+//
+//	var w = tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+//	fmt.Fprintf(w, "%s\t%s\n", aurora.Bold("NAME"), aurora.Bold("STATUS"))
+//	fmt.Fprintf(w, "%s\t%s\n", "web", aurora.Green("Running"))
+//	w.Flush()
+//
+// Escape sequences are never split and never terminate a cell, even if
+// they contain tabs or newlines. Escape sequences can be split between
+// writes.
+package tabwriter
+
+import (
+	"io"
+	"unicode/utf8"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+// Formatting can be controlled with these flags.
+const (
+	// Ignore html tags and treat entities (starting with '&'
+	// and ending in ';') as single characters (width = 1).
+	FilterHTML uint = 1 << iota
+
+	// Strip Escape characters bracketing escaped text segments
+	// instead of passing them through unchanged with the text.
+	StripEscape
+
+	// Force right-alignment of cell content.
+	// Default is left-alignment.
+	AlignRight
+
+	// Handle empty columns as if they were not present in
+	// the input in the first place.
+	DiscardEmptyColumns
+
+	// Always use tabs for indentation columns (i.e., padding of
+	// leading empty cells on the left) independent of padchar.
+	TabIndent
+
+	// Print a vertical bar ('|') between columns (after formatting).
+	// Discarded columns appear as zero-width columns ("||").
+	Debug
+)
+
+// Escape character used to escape text segments. To escape a tab or a
+// line break put it between two Escape characters. The Escape characters
+// are not the ANSI escape sequences which are handled automatically.
+// Unlike the text/tabwriter, width of escaped text is its display width,
+// thus escaped tabs and line breaks take no columns.
+const Escape = '\xff'
+
+// A cell represents a segment of text terminated by tabs or line breaks.
+type cell struct {
+	size  int  // cell size in bytes
+	width int  // cell display width
+	htab  bool // true if the cell is terminated by an htab ('\t')
+}
+
+// states of ANSI escape sequence scanner
+const (
+	ansiNone   = iota // not in a sequence
+	ansiEsc           // after ESC
+	ansiCSI           // in CSI sequence
+	ansiStr           // in OSC, DCS, SOS, PM or APC sequence
+	ansiStrEsc        // ESC in OSC, DCS, SOS, PM or APC sequence
+)
+
+// A Writer is a filter that inserts padding around tab-delimited columns
+// in its input to align them in the output. See text/tabwriter for
+// details. The difference is that the Writer measures cells by display
+// width, skipping ANSI escape sequences.
+//
+// The Writer must buffer input internally, because proper spacing of one
+// line may depend on the cells in future lines. Clients must call Flush
+// when done calling Write.
+type Writer struct {
+	// configuration
+	output   io.Writer
+	minwidth int
+	tabwidth int
+	padding  int
+	padchar  byte
+	flags    uint
+
+	// current state
+	buf     []byte   // collected text excluding tabs or line breaks
+	pos     int      // buffer position up to which cell.width is updated
+	cell    cell     // current incomplete cell
+	endChar byte     // terminating char of escaped sequence
+	ansi    int      // ANSI escape sequence scanner state
+	lines   [][]cell // list of lines; each line is a list of cells
+	widths  []int    // list of column widths in runes - re-used
+}
+
+// NewWriter allocates and initializes a new Writer. The parameters are
+// the same as for the Init method.
+func NewWriter(output io.Writer, minwidth, tabwidth, padding int,
+	padchar byte, flags uint) *Writer {
+
+	return new(Writer).Init(output, minwidth, tabwidth, padding, padchar,
+		flags)
+}
+
+// Init initializes a Writer. The first parameter (output) specifies
+// the filter output. The remaining parameters control the formatting:
+//
+//	minwidth	minimal cell width including any padding
+//	tabwidth	width of tab characters (equivalent number of spaces)
+//	padding		padding added to a cell before computing its width
+//	padchar		ASCII char used for padding
+//			if padchar == '\t', the Writer will assume that the
+//			width of a '\t' in the formatted output is tabwidth,
+//			and cells are left-aligned independent of align_left
+//			(for correct-looking results, tabwidth must correspond
+//			to the tab width in the viewer displaying the result)
+//	flags		formatting control
+func (w *Writer) Init(output io.Writer, minwidth, tabwidth, padding int,
+	padchar byte, flags uint) *Writer {
+
+	if minwidth < 0 || tabwidth < 0 || padding < 0 {
+		panic("negative minwidth, tabwidth, or padding")
+	}
+	w.output = output
+	w.minwidth = minwidth
+	w.tabwidth = tabwidth
+	w.padding = padding
+	w.padchar = padchar
+	if padchar == '\t' {
+		// tab padding enforces left-alignment
+		flags &^= AlignRight
+	}
+	w.flags = flags
+	w.reset()
+	return w
+}
+
+func (w *Writer) reset() {
+	w.buf = w.buf[:0]
+	w.pos = 0
+	w.cell = cell{}
+	w.endChar = 0
+	w.ansi = ansiNone
+	w.lines = w.lines[0:0]
+	w.widths = w.widths[0:0]
+	w.addLine(true)
+}
+
+// addLine adds a new line; flushed is a hint indicating whether
+// the underlying writer was just flushed
+func (w *Writer) addLine(flushed bool) {
+	// grow slice instead of appending, to reuse existing cells
+	if n := len(w.lines) + 1; n <= cap(w.lines) {
+		w.lines = w.lines[:n]
+		w.lines[n-1] = w.lines[n-1][:0]
+	} else {
+		w.lines = append(w.lines, nil)
+	}
+	if !flushed {
+		// the previous line is probably a good indicator of how
+		// many cells the current line will have
+		if n := len(w.lines); n >= 2 {
+			if prev := len(w.lines[n-2]); prev > cap(w.lines[n-1]) {
+				w.lines[n-1] = make([]cell, 0, prev)
+			}
+		}
+	}
+}
+
+// osError is used to propagate errors of the output through panics
+type osError struct {
+	err error
+}
+
+func (w *Writer) write0(buf []byte) {
+	var n, err = w.output.Write(buf)
+	if n != len(buf) && err == nil {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		panic(osError{err})
+	}
+}
+
+var (
+	tabs    = []byte("\t\t\t\t\t\t\t\t")
+	vbar    = []byte{'|'}
+	hbar    = []byte("---\n")
+	newline = []byte{'\n'}
+)
+
+func (w *Writer) writeN(src []byte, n int) {
+	for n > len(src) {
+		w.write0(src)
+		n -= len(src)
+	}
+	w.write0(src[0:n])
+}
+
+func (w *Writer) writePadding(textw, cellw int, useTabs bool) {
+	if w.padchar == '\t' || useTabs {
+		// padding is done with tabs
+		if w.tabwidth == 0 {
+			return // tabs have no width - can't do any padding
+		}
+		// make cellw the smallest multiple of w.tabwidth
+		cellw = (cellw + w.tabwidth - 1) / w.tabwidth * w.tabwidth
+		var n = cellw - textw // amount of padding
+		if n < 0 {
+			panic("internal error")
+		}
+		w.writeN(tabs, (n+w.tabwidth-1)/w.tabwidth)
+		return
+	}
+	// padding is done with non-tab characters
+	var pad = [8]byte{}
+	for i := range pad {
+		pad[i] = w.padchar
+	}
+	w.writeN(pad[:], cellw-textw)
+}
+
+func (w *Writer) writeLines(pos0 int, line0, line1 int) (pos int) {
+	pos = pos0
+	for i := line0; i < line1; i++ {
+		var (
+			line    = w.lines[i]
+			useTabs = w.flags&TabIndent != 0 // leading empty cells
+		)
+		for j, c := range line {
+			if j > 0 && w.flags&Debug != 0 {
+				w.write0(vbar) // indicate column break
+			}
+			if c.size == 0 {
+				// empty cell
+				if j < len(w.widths) {
+					w.writePadding(c.width, w.widths[j], useTabs)
+				}
+				continue
+			}
+			// non-empty cell
+			useTabs = false
+			if w.flags&AlignRight == 0 { // align left
+				w.write0(w.buf[pos : pos+c.size])
+				pos += c.size
+				if j < len(w.widths) {
+					w.writePadding(c.width, w.widths[j], false)
+				}
+			} else { // align right
+				if j < len(w.widths) {
+					w.writePadding(c.width, w.widths[j], false)
+				}
+				w.write0(w.buf[pos : pos+c.size])
+				pos += c.size
+			}
+		}
+		if i+1 == len(w.lines) {
+			// last buffered line - we don't have a newline, so just write
+			// any outstanding buffered data
+			w.write0(w.buf[pos : pos+w.cell.size])
+			pos += w.cell.size
+		} else {
+			// not the last line - write newline
+			w.write0(newline)
+		}
+	}
+	return
+}
+
+// format the text between line0 and line1 (excluding line1); pos is the
+// buffer position corresponding to the beginning of line0; returns the
+// buffer position corresponding to the beginning of line1
+func (w *Writer) format(pos0 int, line0, line1 int) (pos int) {
+	pos = pos0
+	var column = len(w.widths)
+	for this := line0; this < line1; this++ {
+		var line = w.lines[this]
+		if column >= len(line)-1 {
+			continue
+		}
+		// cell exists in this column => this line has more cells than
+		// the previous line (the last cell per line is ignored because
+		// cells are tab-terminated; the last cell per line describes the
+		// text before the newline/formfeed and does not belong to a
+		// column)
+
+		// print unprinted lines until beginning of block
+		pos = w.writeLines(pos, line0, this)
+		line0 = this
+
+		// column block begin
+		var (
+			width       = w.minwidth // minimal column width
+			discardable = true       // true if all cells in this column are empty and "soft"
+		)
+		for ; this < line1; this++ {
+			line = w.lines[this]
+			if column >= len(line)-1 {
+				break
+			}
+			// cell exists in this column
+			var c = line[column]
+			// update width
+			if cw := c.width + w.padding; cw > width {
+				width = cw
+			}
+			// update discardable
+			if c.width > 0 || c.htab {
+				discardable = false
+			}
+		}
+		// column block end
+
+		// discard empty columns if necessary
+		if discardable && w.flags&DiscardEmptyColumns != 0 {
+			width = 0
+		}
+
+		// format and print all columns to the right of this column
+		// (we know the widths of this column and all columns to the left)
+		w.widths = append(w.widths, width) // push width
+		pos = w.format(pos, line0, this)
+		w.widths = w.widths[0 : len(w.widths)-1] // pop width
+		line0 = this
+	}
+
+	// print unprinted lines until end
+	return w.writeLines(pos, line0, line1)
+}
+
+// append text to current cell
+func (w *Writer) append(text []byte) {
+	w.buf = append(w.buf, text...)
+	w.cell.size += len(text)
+}
+
+// updateWidth updates the cell width by display width of complete runes
+// collected since the last update.
+func (w *Writer) updateWidth() {
+	var text = w.buf[w.pos:]
+	for len(text) > 0 {
+		if text[0] < utf8.RuneSelf {
+			if text[0] >= 0x20 && text[0] != 0x7f {
+				w.cell.width++
+			}
+			text = text[1:]
+			continue
+		}
+		if !utf8.FullRune(text) {
+			break // wait for rest of the rune
+		}
+		var r, size = utf8.DecodeRune(text)
+		w.cell.width += aurora.RuneWidth(r)
+		text = text[size:]
+	}
+	w.pos = len(w.buf) - len(text)
+}
+
+// terminate the current cell by adding it to the list of cells of the
+// current line; returns the number of cells in that line
+func (w *Writer) terminateCell(htab bool) int {
+	w.pos = len(w.buf) // drop incomplete rune, if any
+	w.cell.htab = htab
+	var line = &w.lines[len(w.lines)-1]
+	*line = append(*line, w.cell)
+	w.cell = cell{}
+	return len(*line)
+}
+
+func (w *Writer) handlePanic(err *error, op string) {
+	if e := recover(); e != nil {
+		if op == "Flush" {
+			// if Flush ran into a panic, we still need to reset
+			w.reset()
+		}
+		if nerr, ok := e.(osError); ok {
+			*err = nerr.err
+			return
+		}
+		panic("tabwriter: panic during " + op)
+	}
+}
+
+// Flush should be called after the last call to Write to ensure
+// that any data buffered in the Writer is written to output. Any
+// incomplete escape sequence at the end is considered
+// complete for formatting purposes.
+func (w *Writer) Flush() error {
+	return w.flush()
+}
+
+// flush is the internal version of Flush, with a named return value which
+// we don't want to expose
+func (w *Writer) flush() (err error) {
+	defer w.handlePanic(&err, "Flush")
+	w.flushNoDefers()
+	return nil
+}
+
+// flushNoDefers is like flush, but without a deferred handlePanic call.
+// This can be called from other methods which already have their own
+// deferred handlePanic calls, such as Write, and avoid the extra defer
+// work.
+func (w *Writer) flushNoDefers() {
+	// add current cell if not empty
+	if w.cell.size > 0 {
+		if w.endChar != 0 {
+			// inside escape - terminate it even if incomplete
+			w.endEscape()
+		}
+		w.updateWidth()
+		w.terminateCell(false)
+	}
+	// format contents of buffer
+	w.format(0, 0, len(w.lines))
+	w.reset()
+}
+
+// start escaped mode
+func (w *Writer) startEscape(ch byte) {
+	switch ch {
+	case Escape:
+		w.endChar = Escape
+	case '<':
+		w.endChar = '>'
+	case '&':
+		w.endChar = ';'
+	}
+}
+
+// terminate escaped mode; if the escaped text was an HTML tag, its width
+// is assumed to be zero for formatting purposes; if it was an HTML entity,
+// its width is assumed to be one; in all other cases, the width is the
+// display width of the escaped text
+func (w *Writer) endEscape() {
+	switch w.endChar {
+	case Escape:
+		// the escaped text can contain ANSI escape sequences
+		w.cell.width += aurora.DisplayWidth(string(w.buf[w.pos:]))
+		if w.flags&StripEscape == 0 {
+			w.cell.width -= 2 // don't count the Escape chars
+		}
+	case '>': // tag of zero width
+	case ';':
+		w.cell.width++ // entity, count as one rune
+	}
+	w.pos = len(w.buf)
+	w.endChar = 0
+}
+
+// scanANSI moves the ANSI escape sequence scanner to next state, returning
+// true if the sequence is complete.
+func (w *Writer) scanANSI(ch byte) (done bool) {
+	switch w.ansi {
+	case ansiEsc:
+		switch {
+		case ch == '[':
+			w.ansi = ansiCSI
+		case ch == ']', ch == 'P', ch == 'X', ch == '^', ch == '_':
+			w.ansi = ansiStr
+		case 0x20 <= ch && ch <= 0x2f:
+			// intermediate byte
+		default:
+			w.ansi = ansiNone
+		}
+	case ansiCSI:
+		if 0x40 <= ch && ch <= 0x7e {
+			w.ansi = ansiNone
+		}
+	case ansiStr:
+		switch ch {
+		case '\a':
+			w.ansi = ansiNone
+		case '\033':
+			w.ansi = ansiStrEsc
+		}
+	case ansiStrEsc:
+		if ch == '\\' {
+			w.ansi = ansiNone
+		} else {
+			w.ansi = ansiStr
+		}
+	}
+	return w.ansi == ansiNone
+}
+
+// Write writes buf to the writer w. The only errors returned are ones
+// encountered while writing to the underlying output stream.
+func (w *Writer) Write(buf []byte) (n int, err error) {
+	defer w.handlePanic(&err, "Write")
+
+	// split text into cells
+	n = 0
+	for i, ch := range buf {
+		if w.ansi != ansiNone {
+			// inside ANSI escape sequence, it takes no space
+			if w.scanANSI(ch) {
+				w.append(buf[n : i+1])
+				w.pos = len(w.buf)
+				n = i + 1
+			}
+			continue
+		}
+		if w.endChar == 0 {
+			// outside escape
+			switch ch {
+			case '\t', '\v', '\n', '\f':
+				// end of cell
+				w.append(buf[n:i])
+				w.updateWidth()
+				n = i + 1 // ch consumed
+				var ncells = w.terminateCell(ch == '\t')
+				if ch == '\n' || ch == '\f' {
+					// terminate line
+					w.addLine(ch == '\f')
+					if ch == '\f' || ncells == 1 {
+						// A '\f' always forces a flush. Otherwise, if the
+						// previous line has only one cell which does not
+						// have an impact on the formatting of the
+						// following lines (the last cell per line is
+						// ignored by format()), thus we can flush the
+						// Writer contents.
+						w.flushNoDefers()
+						if ch == '\f' && w.flags&Debug != 0 {
+							// indicate section break
+							w.write0(hbar)
+						}
+					}
+				}
+
+			case '\033':
+				// start of ANSI escape sequence
+				w.append(buf[n:i])
+				w.updateWidth()
+				n = i
+				w.ansi = ansiEsc
+
+			case Escape:
+				// start of escaped sequence
+				w.append(buf[n:i])
+				w.updateWidth()
+				n = i
+				if w.flags&StripEscape != 0 {
+					n++ // strip Escape
+				}
+				w.startEscape(Escape)
+
+			case '<', '&':
+				// possibly an html tag/entity
+				if w.flags&FilterHTML != 0 {
+					// begin of tag/entity
+					w.append(buf[n:i])
+					w.updateWidth()
+					n = i
+					w.startEscape(ch)
+				}
+			}
+
+		} else {
+			// inside escape
+			if ch == w.endChar {
+				// end of tag/entity
+				var j = i + 1
+				if ch == Escape && w.flags&StripEscape != 0 {
+					j = i // strip Escape
+				}
+				w.append(buf[n:j])
+				n = i + 1 // ch consumed
+				w.endEscape()
+			}
+		}
+	}
+
+	// append leftover text
+	w.append(buf[n:])
+	if w.ansi != ansiNone {
+		w.pos = len(w.buf) // incomplete ANSI escape sequence
+	}
+	n = len(buf)
+	return
+}
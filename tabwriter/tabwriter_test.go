@@ -0,0 +1,236 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package tabwriter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+type config struct {
+	minwidth, tabwidth, padding int
+	padchar                     byte
+	flags                       uint
+}
+
+var configs = []config{
+	{0, 8, 1, ' ', 0},
+	{0, 8, 1, '.', 0},
+	{8, 8, 1, ' ', 0},
+	{0, 8, 1, '\t', 0},
+	{0, 4, 0, '\t', TabIndent},
+	{0, 8, 1, ' ', AlignRight},
+	{0, 8, 1, ' ', Debug},
+	{0, 8, 1, ' ', DiscardEmptyColumns | Debug},
+	{0, 8, 1, '-', TabIndent | Debug},
+	{0, 8, 1, ' ', FilterHTML},
+	{0, 8, 1, ' ', StripEscape},
+	{0, 8, 1, ' ', FilterHTML | StripEscape},
+}
+
+var inputs = []string{
+	"",
+	"a",
+	"a\tb\tc\n",
+	"a\tb\tc",
+	"NAME\tSTATUS\tAGE\nweb\tRunning\t1d\nworker-1\tCrashLoopBackOff\t12h\n",
+	"a\tbb\tccc\ndddd\teeeee\tf\n\nx\ty\n",
+	"\t\tindented\n\t\tmore\n",
+	"a\t\tb\n\t\t\nc\t\td\n",
+	"a\vb\vc\nddd\veee\vf\n",
+	"a\tb\fccc\tddd\n",
+	"<b>bold</b>\tx\n&amp;\ty\n",
+	"\xffa&b\xff\tc\ndd\te\n",
+	"héllo\tx\nhi\ty\n",
+}
+
+type writer interface {
+	Write([]byte) (int, error)
+	Flush() error
+}
+
+func std(buf *bytes.Buffer, c config) writer {
+	return tabwriter.NewWriter(buf, c.minwidth, c.tabwidth, c.padding,
+		c.padchar, c.flags)
+}
+
+func our(buf *bytes.Buffer, c config) writer {
+	return NewWriter(buf, c.minwidth, c.tabwidth, c.padding, c.padchar,
+		c.flags)
+}
+
+// write given input by chunks of given size (all at once if chunk is 0)
+func write(t *testing.T, newWriter func(*bytes.Buffer, config) writer,
+	c config, chunk int, input string) string {
+
+	var (
+		buf  bytes.Buffer
+		w    = newWriter(&buf, c)
+		data = []byte(input)
+	)
+	for len(data) > 0 {
+		var n = chunk
+		if n <= 0 || n > len(data) {
+			n = len(data)
+		}
+		var m, err = w.Write(data[:n])
+		require.NoError(t, err)
+		require.Equal(t, n, m)
+		data = data[n:]
+	}
+	require.NoError(t, w.Flush())
+	return buf.String()
+}
+
+// colors and hyperlinks are enabled regardless of output
+var au = aurora.New()
+
+// colorize wraps every cell of given input in colors and some of them
+// in hyperlinks
+func colorize(input string) string {
+	var (
+		out  []byte
+		cell []byte
+		i    int
+	)
+	var flush = func() {
+		if len(cell) == 0 {
+			return
+		}
+		var v = au.Red(string(cell))
+		if i%2 == 1 {
+			v = au.Hyperlink(au.Bold(v), "http://example.com/")
+		}
+		i++
+		out = append(out, v.String()...)
+		cell = cell[:0]
+	}
+	for _, b := range []byte(input) {
+		switch b {
+		case '\t', '\v', '\n', '\f':
+			flush()
+			out = append(out, b)
+		default:
+			cell = append(cell, b)
+		}
+	}
+	flush()
+	return string(out)
+}
+
+func TestWriter_plain(t *testing.T) {
+	for _, c := range configs {
+		for _, input := range inputs {
+			for _, chunk := range []int{0, 1, 2, 3} {
+				assert.Equal(t,
+					write(t, std, c, chunk, input),
+					write(t, our, c, chunk, input),
+					"%+v %q %d", c, input, chunk)
+			}
+		}
+	}
+}
+
+func TestWriter_colored(t *testing.T) {
+	for _, c := range configs {
+		for _, input := range inputs {
+			var colored = colorize(input)
+			for _, chunk := range []int{0, 1, 2, 3} {
+				var got = write(t, our, c, chunk, colored)
+				assert.Equal(t,
+					write(t, std, c, chunk, input),
+					aurora.Strip(got),
+					"%+v %q %d", c, colored, chunk)
+			}
+		}
+	}
+}
+
+func TestWriter_wide(t *testing.T) {
+	var got = write(t, our, configs[0], 0,
+		"世界\tx\nabc\ty\nhe\u0301llo\tz\n")
+	assert.Equal(t, "世界  x\nabc   y\nhe\u0301llo z\n", got)
+}
+
+func TestWriter_escapes(t *testing.T) {
+	// tabs and line breaks in escape sequences don't break cells
+	var input = "\033]8;;http://x/?a=\tb\033\\link\033]8;;\033\\\tx\n" +
+		"abcdefgh\ty\n"
+	var got = write(t, our, configs[0], 1, input)
+	assert.Equal(t, "\033]8;;http://x/?a=\tb\033\\link\033]8;;\033\\     x\n"+
+		"abcdefgh y\n", got)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("test")
+}
+
+func TestWriter_error(t *testing.T) {
+	var w = NewWriter(errWriter{}, 0, 8, 1, ' ', 0)
+	var _, err = w.Write([]byte("a\n"))
+	assert.EqualError(t, err, "test")
+	_, err = w.Write([]byte("a\tb\tc\nd\te\tf\n"))
+	assert.NoError(t, err)
+	assert.EqualError(t, w.Flush(), "test")
+}
+
+func TestNewWriter_panic(t *testing.T) {
+	assert.Panics(t, func() { NewWriter(os.Stdout, -1, 8, 1, ' ', 0) })
+}
+
+func Example() {
+	var w = NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\n", au.Bold("NAME"), au.Bold("STATUS"))
+	fmt.Fprintf(w, "%s\t%s\n", "web", au.Green("Running"))
+	fmt.Fprintf(w, "%s\t%s\n", "worker", au.Red("Failed"))
+	w.Flush()
+
+	// Output:
+	// [1mNAME[0m   [1mSTATUS[0m
+	// web    [32mRunning[0m
+	// worker [31mFailed[0m
+}
@@ -0,0 +1,129 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+// Semantic roles of a Theme.
+const (
+	RoleError     = "error"     // errors
+	RoleWarning   = "warning"   // warnings
+	RoleSuccess   = "success"   // successful results
+	RoleInfo      = "info"      // informational messages
+	RoleMuted     = "muted"     // secondary, less important text
+	RoleHighlight = "highlight" // text that should be noticed
+	RoleKey       = "key"       // keys of key-value pairs
+	RoleValue     = "value"     // values of key-value pairs
+	RoleLink      = "link"      // links
+)
+
+// A Theme maps semantic roles to Styles. A Theme can be decoded from
+// JSON, YAML, TOML, etc, where every Style represented as a string. For
+// example, in YAML
+//
+//	theme:
+//	  error: bold red
+//	  warning: yellow
+//	  muted: bright-black
+//	  link: underline blue
+//
+// See the Style's UnmarshalText method for details.
+type Theme struct {
+	Error     Style `json:"error" yaml:"error" toml:"error" mapstructure:"error"`
+	Warning   Style `json:"warning" yaml:"warning" toml:"warning" mapstructure:"warning"`
+	Success   Style `json:"success" yaml:"success" toml:"success" mapstructure:"success"`
+	Info      Style `json:"info" yaml:"info" toml:"info" mapstructure:"info"`
+	Muted     Style `json:"muted" yaml:"muted" toml:"muted" mapstructure:"muted"`
+	Highlight Style `json:"highlight" yaml:"highlight" toml:"highlight" mapstructure:"highlight"`
+	Key       Style `json:"key" yaml:"key" toml:"key" mapstructure:"key"`
+	Value     Style `json:"value" yaml:"value" toml:"value" mapstructure:"value"`
+	Link      Style `json:"link" yaml:"link" toml:"link" mapstructure:"link"`
+}
+
+// DefaultTheme returns new default Theme.
+func DefaultTheme() (t Theme) {
+	t.Error = NewStyle().Red().Bold()
+	t.Warning = NewStyle().Yellow()
+	t.Success = NewStyle().Green()
+	t.Info = NewStyle().Cyan()
+	t.Muted = NewStyle().BrightBlack()
+	t.Highlight = NewStyle().Bold()
+	t.Key = NewStyle().Blue()
+	t.Value = NewStyle()
+	t.Link = NewStyle().Blue().Underline()
+	return
+}
+
+func (t *Theme) role(role string) (style *Style) {
+	switch role {
+	case RoleError:
+		return &t.Error
+	case RoleWarning:
+		return &t.Warning
+	case RoleSuccess:
+		return &t.Success
+	case RoleInfo:
+		return &t.Info
+	case RoleMuted:
+		return &t.Muted
+	case RoleHighlight:
+		return &t.Highlight
+	case RoleKey:
+		return &t.Key
+	case RoleValue:
+		return &t.Value
+	case RoleLink:
+		return &t.Link
+	}
+	return // nil
+}
+
+// Style of given role. It returns false for unknown role.
+func (t *Theme) Style(role string) (style Style, ok bool) {
+	var sp *Style
+	if sp = t.role(role); sp == nil {
+		return // zero, false
+	}
+	return *sp, true
+}
+
+// SetStyle of given role. It returns false for unknown role.
+func (t *Theme) SetStyle(role string, style Style) (ok bool) {
+	var sp *Style
+	if sp = t.role(role); sp == nil {
+		return // false
+	}
+	*sp = style
+	return true
+}
@@ -0,0 +1,95 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var allRoles = []string{
+	RoleError,
+	RoleWarning,
+	RoleSuccess,
+	RoleInfo,
+	RoleMuted,
+	RoleHighlight,
+	RoleKey,
+	RoleValue,
+	RoleLink,
+}
+
+func TestDefaultTheme(t *testing.T) {
+	var theme = DefaultTheme()
+	assert.Equal(t, RedFg|BoldFm, theme.Error.Color())
+	assert.Equal(t, BlueFg|UnderlineFm, theme.Link.Color())
+}
+
+func TestTheme_Style(t *testing.T) {
+	var theme Theme
+	for i, role := range allRoles {
+		var style = NewStyle().Index(ColorIndex(i + 1))
+		assert.True(t, theme.SetStyle(role, style), role)
+		var got, ok = theme.Style(role)
+		assert.True(t, ok, role)
+		assert.Equal(t, style, got, role)
+	}
+	assert.False(t, theme.SetStyle("unknown", NewStyle().Red()))
+	var _, ok = theme.Style("unknown")
+	assert.False(t, ok)
+}
+
+func TestAurora_Role(t *testing.T) {
+	var au = New()
+	assert.Equal(t, "\033[1;31mx\033[0m", au.Role(RoleError, "x").String())
+	assert.Equal(t, "\033[1;31mx\033[0m",
+		au.Role(RoleError, Blue("x")).String())
+	assert.Equal(t, "x", au.Role("unknown", "x").String())
+	assert.Equal(t, "\033[34mx\033[0m", au.Role("unknown", Blue("x")).String())
+	// custom theme
+	var theme = DefaultTheme()
+	theme.Error = NewStyle().Magenta()
+	au = New(WithTheme(theme))
+	assert.Equal(t, "\033[35mx\033[0m", au.Role(RoleError, "x").String())
+	// no colors
+	au = New(WithColors(false))
+	assert.Equal(t, "x", au.Role(RoleError, "x").String())
+}
+
+func TestRole(t *testing.T) {
+	assert.Equal(t, "\033[33mx\033[0m", Role(RoleWarning, "x").String())
+}
@@ -0,0 +1,318 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+// Package tree renders hierarchical data, like file trees, dependency
+// graphs or span trees.
+//
+//	var root = tree.New(aurora.Bold("project"))
+//	var cmd = root.Add("cmd")
+//	cmd.Add("main.go").Hyperlink("file:///project/cmd/main.go")
+//	root.Add(aurora.Green("go.mod"))
+//	fmt.Print(root)
+//
+// Output of the example is
+//
+//	project
+//	├── cmd
+//	│   └── main.go
+//	└── go.mod
+//
+// Nodes can be any values, including aurora Values with colors and
+// hyperlinks. ASCII glyphs are used for terminals without UTF-8 support.
+package tree
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+// A Node of a tree.
+type Node struct {
+	Value     interface{} // value of the node, can be an aurora.Value
+	Link      string      // hyperlink target, optional
+	Collapsed bool        // hide children of the node
+	Children  []*Node     // children of the node
+}
+
+// New Node with given value.
+func New(val interface{}) *Node {
+	return &Node{Value: val}
+}
+
+// Add a child with given value returning the child.
+func (n *Node) Add(val interface{}) (child *Node) {
+	child = New(val)
+	n.Children = append(n.Children, child)
+	return
+}
+
+// Append given children returning the Node.
+func (n *Node) Append(children ...*Node) *Node {
+	n.Children = append(n.Children, children...)
+	return n
+}
+
+// Hyperlink sets hyperlink target of the Node, like file:// path,
+// returning the Node.
+func (n *Node) Hyperlink(target string) *Node {
+	n.Link = target
+	return n
+}
+
+// Collapse hides children of the Node returning the Node.
+func (n *Node) Collapse() *Node {
+	n.Collapsed = true
+	return n
+}
+
+// String renders the Node and its children using NewPrinter.
+func (n *Node) String() string {
+	return string(NewPrinter().Bytes(n))
+}
+
+// count descendants of the Node up to given number of levels, where
+// zero or negative levels means no limit; the path holds nodes being
+// rendered, a child from the path is counted, but not its descendants
+func (n *Node) count(levels int, path map[*Node]bool) (c int) {
+	path[n] = true
+	defer delete(path, n)
+	for _, child := range n.Children {
+		c++
+		if levels != 1 && !path[child] {
+			c += child.count(levels-1, path)
+		}
+	}
+	return
+}
+
+// Glyphs used to draw branches of a tree. All glyphs should have the
+// same display width.
+type Glyphs struct {
+	Branch   string // a child that is not last, "├── "
+	Last     string // last child, "└── "
+	Vertical string // continuation of a branch, "│   "
+	Space    string // no branch, "    "
+}
+
+// Predefined glyphs.
+var (
+	UnicodeGlyphs = Glyphs{
+		Branch:   "├── ",
+		Last:     "└── ",
+		Vertical: "│   ",
+		Space:    "    ",
+	}
+	ASCIIGlyphs = Glyphs{
+		Branch:   "|-- ",
+		Last:     "`-- ",
+		Vertical: "|   ",
+		Space:    "    ",
+	}
+)
+
+// IsUTF8 reports whether locale of the terminal, defined by LC_ALL,
+// LC_CTYPE and LANG environment variables, uses the UTF-8 encoding. If
+// no locale set, then it's true for all systems except Windows, where
+// console uses a code page.
+func IsUTF8() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if val := os.Getenv(name); val != "" {
+			val = strings.ToLower(val)
+			return strings.Contains(val, "utf-8") ||
+				strings.Contains(val, "utf8")
+		}
+	}
+	return os.PathSeparator == '/'
+}
+
+// A Printer renders trees. Fields of the Printer can be changed before
+// rendering. The Printer is safe for concurrent use if not changed.
+type Printer struct {
+	// Colorizer used to render trees. The nil means the aurora.Default().
+	Colorizer aurora.Colorizer
+	// Glyphs of branches. The zero Glyphs means the UnicodeGlyphs, or the
+	// ASCIIGlyphs if the IsUTF8 returns false.
+	Glyphs Glyphs
+	// GlyphStyle is style of branches and collapsed nodes markers.
+	GlyphStyle aurora.Style
+	// MaxDepth of rendered nodes. Deeper nodes are collapsed. The root
+	// has depth 0. Zero means no limit. The MaxDepth also limits depth
+	// of hidden nodes counted for collapsed nodes markers.
+	MaxDepth int
+}
+
+// NewPrinter returns Printer with muted branches.
+func NewPrinter() *Printer {
+	return &Printer{
+		GlyphStyle: aurora.NewStyle().BrightBlack(),
+	}
+}
+
+// renderer of a tree
+type renderer struct {
+	p      *Printer
+	au     aurora.Colorizer
+	links  bool
+	glyphs Glyphs
+	path   map[*Node]bool // nodes being rendered, to detect cycles
+	buf    bytes.Buffer
+}
+
+// Bytes returns rendered tree.
+func (p *Printer) Bytes(root *Node) []byte {
+	var r = renderer{
+		p:      p,
+		au:     p.Colorizer,
+		glyphs: p.Glyphs,
+		path:   make(map[*Node]bool),
+	}
+	if r.au == nil {
+		r.au = aurora.Default()
+	}
+	r.links = r.au.Config().Hyperlinks
+	if r.glyphs == (Glyphs{}) {
+		if IsUTF8() {
+			r.glyphs = UnicodeGlyphs
+		} else {
+			r.glyphs = ASCIIGlyphs
+		}
+	}
+	if root != nil {
+		r.node(root, 0, nil, nil)
+	}
+	return r.buf.Bytes()
+}
+
+// String returns rendered tree.
+func (p *Printer) String(root *Node) string {
+	return string(p.Bytes(root))
+}
+
+// Print renders the tree to given writer.
+func (p *Printer) Print(w io.Writer, root *Node) (err error) {
+	_, err = w.Write(p.Bytes(root))
+	return
+}
+
+// glyph returns styled glyph
+func (r *renderer) glyph(glyph string) aurora.Value {
+	return r.au.Colorize(glyph, r.p.GlyphStyle.Color()).Merge(r.p.GlyphStyle)
+}
+
+// with returns copy of given segments of a line extended by given one
+func with(segs []aurora.Value, seg aurora.Value) []aurora.Value {
+	return append(segs[:len(segs):len(segs)], seg)
+}
+
+// lines of a node
+func (r *renderer) lines(n *Node) (lines []aurora.Value) {
+	var (
+		val    = n.Value
+		color  aurora.Color
+		target = n.Link
+		params []aurora.HyperlinkParam
+	)
+	if v, ok := val.(aurora.Value); ok {
+		color, val = v.Color(), v.Value()
+		if target == "" {
+			target, params = v.HyperlinkTarget(), v.HyperlinkParams()
+		}
+	}
+	var text string
+	if val != nil {
+		text = fmt.Sprint(val)
+	}
+	if !r.au.Config().Colors {
+		text = aurora.Strip(text)
+	}
+	for _, line := range strings.Split(text, "\n") {
+		var out = r.au.Colorize(line, color)
+		if target != "" && r.links {
+			out = r.au.Hyperlink(out, target, params...)
+		}
+		lines = append(lines, out)
+	}
+	return
+}
+
+// node renders given node, where the first is prefix of the first line,
+// and the prefix is prefix of other lines and children; a node that is
+// its own ancestor is rendered without children and marked as a cycle;
+// lines are joined with minimal transitions between styles
+func (r *renderer) node(n *Node, depth int, first, prefix []aurora.Value) {
+	var (
+		cycle  = r.path[n]
+		hidden = len(n.Children) > 0 && !cycle &&
+			(n.Collapsed || (r.p.MaxDepth > 0 && depth >= r.p.MaxDepth))
+		lines = r.lines(n)
+	)
+	for i, line := range lines {
+		var segs = prefix // under the first line
+		if i == 0 {
+			segs = first
+		}
+		segs = with(segs, line)
+		if i == len(lines)-1 {
+			switch {
+			case cycle:
+				segs = append(segs, r.glyph(" [cycle]"))
+			case hidden:
+				segs = append(segs, r.glyph(fmt.Sprintf(" [+%d]",
+					n.count(r.p.MaxDepth, r.path))))
+			}
+		}
+		r.buf.WriteString(aurora.Join(segs...))
+		r.buf.WriteByte('\n')
+	}
+	if hidden || cycle {
+		return
+	}
+	r.path[n] = true
+	defer delete(r.path, n)
+	for i, child := range n.Children {
+		if i == len(n.Children)-1 {
+			r.node(child, depth+1, with(prefix, r.glyph(r.glyphs.Last)),
+				with(prefix, r.au.Reset(r.glyphs.Space)))
+		} else {
+			r.node(child, depth+1, with(prefix, r.glyph(r.glyphs.Branch)),
+				with(prefix, r.glyph(r.glyphs.Vertical)))
+		}
+	}
+}
@@ -0,0 +1,246 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package tree
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+// colors and hyperlinks are enabled regardless of output
+var au = aurora.New()
+
+func sample() (root *Node) {
+	root = New("project")
+	var cmd = root.Add("cmd")
+	cmd.Add("main.go")
+	cmd.Add("flags.go")
+	var pkg = root.Add("pkg")
+	pkg.Add("pkg.go")
+	root.Add("go.mod")
+	return
+}
+
+func plain() *Printer {
+	return &Printer{
+		Colorizer: aurora.New(aurora.WithColors(false)),
+		Glyphs:    UnicodeGlyphs,
+	}
+}
+
+func TestNode(t *testing.T) {
+	var n = New(1)
+	assert.Equal(t, &Node{Value: 1}, n)
+	var c = n.Add(2)
+	assert.Equal(t, &Node{Value: 2}, c)
+	assert.True(t, n == n.Append(New(3)))
+	assert.Equal(t, []*Node{{Value: 2}, {Value: 3}}, n.Children)
+	assert.True(t, c == c.Hyperlink("file:///x").Collapse())
+	assert.Equal(t, &Node{Value: 2, Link: "file:///x", Collapsed: true}, c)
+	assert.Equal(t, 2, n.count(0, map[*Node]bool{}))
+	c.Add(4).Add(5)
+	assert.Equal(t, 4, n.count(0, map[*Node]bool{}))
+	assert.Equal(t, 3, n.count(2, map[*Node]bool{}))
+}
+
+func TestIsUTF8(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+	assert.True(t, IsUTF8())
+	t.Setenv("LC_CTYPE", "C")
+	assert.False(t, IsUTF8())
+	t.Setenv("LC_ALL", "ru_RU.utf8")
+	assert.True(t, IsUTF8())
+}
+
+func TestPrinter_String(t *testing.T) {
+	assert.Equal(t, ""+
+		"project\n"+
+		"├── cmd\n"+
+		"│   ├── main.go\n"+
+		"│   └── flags.go\n"+
+		"├── pkg\n"+
+		"│   └── pkg.go\n"+
+		"└── go.mod\n", plain().String(sample()))
+	assert.Equal(t, "", plain().String(nil))
+}
+
+func TestPrinter_ascii(t *testing.T) {
+	var p = plain()
+	p.Glyphs = Glyphs{}
+	t.Setenv("LC_ALL", "C")
+	assert.Equal(t, ""+
+		"project\n"+
+		"|-- cmd\n"+
+		"|   |-- main.go\n"+
+		"|   `-- flags.go\n"+
+		"|-- pkg\n"+
+		"|   `-- pkg.go\n"+
+		"`-- go.mod\n", p.String(sample()))
+}
+
+func TestPrinter_MaxDepth(t *testing.T) {
+	var p = plain()
+	p.MaxDepth = 1
+	assert.Equal(t, ""+
+		"project\n"+
+		"├── cmd [+2]\n"+
+		"├── pkg [+1]\n"+
+		"└── go.mod\n", p.String(sample()))
+	p.MaxDepth = 0
+	var root = sample()
+	root.Children[0].Collapse()
+	assert.Equal(t, ""+
+		"project\n"+
+		"├── cmd [+2]\n"+
+		"├── pkg\n"+
+		"│   └── pkg.go\n"+
+		"└── go.mod\n", p.String(root))
+}
+
+func TestPrinter_cycle(t *testing.T) {
+	var root = New("a")
+	var b = root.Add("b")
+	b.Append(root)
+	b.Add("c")
+	var p = plain()
+	assert.Equal(t, ""+
+		"a\n"+
+		"└── b\n"+
+		"    ├── a [cycle]\n"+
+		"    └── c\n", p.String(root))
+	p.MaxDepth = 3
+	assert.Equal(t, p.String(root), p.String(root.Children[0].Children[0]))
+	p.MaxDepth = 1
+	assert.Equal(t, ""+
+		"a\n"+
+		"└── b [+2]\n", p.String(root))
+	root.Collapse()
+	p.MaxDepth = 0
+	assert.Equal(t, "a [+3]\n", p.String(root))
+}
+
+func TestPrinter_multiline(t *testing.T) {
+	var root = New("a\nb")
+	root.Add("c\nd").Add("e")
+	root.Add("f\ng")
+	assert.Equal(t, ""+
+		"a\n"+
+		"b\n"+
+		"├── c\n"+
+		"│   d\n"+
+		"│   └── e\n"+
+		"└── f\n"+
+		"    g\n", plain().String(root))
+}
+
+func TestPrinter_colors(t *testing.T) {
+	var p = NewPrinter()
+	p.Colorizer = au
+	p.Glyphs = ASCIIGlyphs
+	var root = New(au.Bold("root"))
+	root.Add(au.Red("file")).Hyperlink("file:///file")
+	root.Add(au.Hyperlink("x", "http://x/"))
+	assert.Equal(t, ""+
+		"\033[1mroot\033[0m\n"+
+		"\033[90m|-- \033]8;;file:///file\033\\\033[31mfile\033[0m\033]8;;\033\\\n"+
+		"\033[90m`-- \033]8;;http://x/\033\\\033[0mx\033]8;;\033\\\n",
+		p.String(root))
+	// adjacent glyphs share a sequence
+	var deep = New("a")
+	deep.Add("b").Add("c")
+	deep.Add("d")
+	assert.Equal(t, ""+
+		"a\n"+
+		"\033[90m|-- \033[0mb\n"+
+		"\033[90m|   `-- \033[0mc\n"+
+		"\033[90m`-- \033[0md\n",
+		p.String(deep))
+	// disabled hyperlinks
+	p.Colorizer = aurora.New(aurora.WithHyperlinks(false))
+	assert.Equal(t, ""+
+		"\033[1mroot\033[0m\n"+
+		"\033[90m|-- \033[31mfile\033[0m\n"+
+		"\033[90m`-- \033[0mx\n",
+		p.String(root))
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("test")
+}
+
+func TestPrinter_Print(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, plain().Print(&buf, New("x")))
+	assert.Equal(t, "x\n", buf.String())
+	assert.EqualError(t, plain().Print(errWriter{}, New("x")), "test")
+}
+
+func TestNode_String(t *testing.T) {
+	t.Setenv("LC_ALL", "C")
+	var root = New("x")
+	root.Add("y")
+	assert.Equal(t, aurora.Strip(root.String()), "x\n`-- y\n")
+}
+
+func Example() {
+	var root = New("project")
+	var cmd = root.Add("cmd")
+	cmd.Add("main.go").Hyperlink("file:///project/cmd/main.go")
+	root.Add("go.mod")
+
+	var p = &Printer{
+		Colorizer: aurora.New(aurora.WithColors(false),
+			aurora.WithHyperlinks(false)),
+		Glyphs: UnicodeGlyphs,
+	}
+	p.Print(os.Stdout, root)
+
+	// Output:
+	// project
+	// ├── cmd
+	// │   └── main.go
+	// └── go.mod
+}
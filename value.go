@@ -36,8 +36,11 @@
 package aurora
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"strconv"
+	"sync"
 	"unicode/utf8"
 )
 
@@ -45,10 +48,11 @@ import (
 var (
 	_ fmt.Stringer  = Value{}
 	_ fmt.Formatter = Value{}
+	_ io.WriterTo   = Value{}
 	_ Colored       = Value{}
 )
 
-func coloredFormat(color Color, s fmt.State, verb rune) string {
+func coloredFormat(v Value, s fmt.State, verb rune) string {
 
 	// it's enough for many cases (%-+020.10f)
 	// %          - 1
@@ -71,12 +75,13 @@ func coloredFormat(color Color, s fmt.State, verb rune) string {
 	//
 	// 10 + 59 * 2 = 128
 
-	var format = make([]byte, 0, 128)
+	var (
+		format = make([]byte, 0, 128)
+		color  = v.Color()
+	)
 
 	if color != 0 {
-		format = append(format, esc...)
-		format = color.appendNos(format, false)
-		format = append(format, 'm')
+		format = v.appendSequence(format, color, false)
 	}
 
 	format = append(format, '%')
@@ -117,8 +122,13 @@ func coloredFormat(color Color, s fmt.State, verb rune) string {
 type colorConfig uint64
 
 const (
-	colorPin      colorConfig = 1 << 32
-	hyperlinksPin colorConfig = 1 << 33
+	colorPin      colorConfig = 1 << 60
+	hyperlinksPin colorConfig = 1 << 61
+
+	shiftProfile             = 62                  // color profile
+	maskProfile  colorConfig = 0x3 << shiftProfile // 2 bits
+
+	maskColor = colorPin - 1 // lower 60 bits are the Color
 )
 
 func (cc colorConfig) colorsEnabled() bool {
@@ -129,15 +139,23 @@ func (cc colorConfig) hyperlinksEnbaled() bool {
 	return cc&hyperlinksPin != 0
 }
 
+func (cc colorConfig) profile() Profile {
+	return Profile((cc & maskProfile) >> shiftProfile)
+}
+
 func (cc colorConfig) color() Color {
-	if cc.colorsEnabled() {
-		return Color(uint32(cc)) // lower 32 bits only
+	if !cc.colorsEnabled() {
+		return 0 // even if a color set
 	}
-	return 0 // even if a color set
+	var c = Color(cc & maskColor)
+	if cc.profile() == ProfileANSI16 {
+		return c.to16()
+	}
+	return c
 }
 
 func (cc colorConfig) resetColor() colorConfig {
-	return cc & (colorPin | hyperlinksPin)
+	return cc & (colorPin | hyperlinksPin | maskProfile)
 }
 
 // A Value represents any printable value
@@ -146,53 +164,235 @@ type Value struct {
 	value     interface{} // value as is
 	cc        colorConfig // color & config
 	hyperlink *hyperlink  // hyperlink target and parameters
+	rgb       *trueColors // 24-bit colors, if any
 }
 
-// String implements standard fmt.Stringer interface.
-func (v Value) String() string {
-	var (
-		t     []byte
-		val   = fmt.Sprint(v.value)
-		color = v.cc.color()
-	)
+// buffers for the WriteTo and the Format
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		var buf = make([]byte, 0, 128)
+		return &buf
+	},
+}
+
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
 
-	if v.cc.hyperlinksEnbaled() && v.hyperlink.isExists() {
-		var (
-			ln  = len(val)
-			nos string
-		)
-		// calculate length
-		ln += v.hyperlink.headLen()
-		if color != 0 {
-			ln += len(esc)
-			nos = color.Nos(false)
-			ln += len(nos) + len("m")
-			ln += len(clear)
+func putBuffer(buf *[]byte) {
+	const maxPooled = 64 << 10 // don't keep too big buffers
+	if cap(*buf) > maxPooled {
+		return
+	}
+	*buf = (*buf)[:0]
+	bufferPool.Put(buf)
+}
+
+// append error or fmt.Stringer, falling back to the fmt package
+// if the method panics (e.g. a nil receiver)
+func appendStringer(dst []byte, val interface{}) (ret []byte) {
+	defer func() {
+		if recover() != nil {
+			ret = fmt.Append(dst, val)
 		}
-		ln += v.hyperlink.tailLen()
-		// fill
-		t = make([]byte, 0, ln)
-		t = append(t, v.hyperlink.headBytes()...)
-		if color != 0 {
-			t = append(t, esc...)
-			t = append(t, nos...)
-			t = append(t, 'm')
-			t = append(t, val...)
-			t = append(t, clear...)
-		} else {
-			t = append(t, val...)
+	}()
+	switch t := val.(type) {
+	case error:
+		return append(dst, t.Error()...)
+	case fmt.Stringer:
+		return append(dst, t.String()...)
+	}
+	return fmt.Append(dst, val)
+}
+
+// append as fmt.Sprint does, avoiding the fmt package for common types
+func appendValue(dst []byte, val interface{}) []byte {
+	switch t := val.(type) {
+	case string:
+		return append(dst, t...)
+	case []byte:
+		// the same as fmt.Sprint does, e.g. [104 105]
+		dst = append(dst, '[')
+		for i, b := range t {
+			if i > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = strconv.AppendUint(dst, uint64(b), 10)
 		}
-		t = append(t, v.hyperlink.tailBytes()...)
-		return string(t)
+		return append(dst, ']')
+	case int:
+		return strconv.AppendInt(dst, int64(t), 10)
+	case int8:
+		return strconv.AppendInt(dst, int64(t), 10)
+	case int16:
+		return strconv.AppendInt(dst, int64(t), 10)
+	case int32:
+		return strconv.AppendInt(dst, int64(t), 10)
+	case int64:
+		return strconv.AppendInt(dst, t, 10)
+	case uint:
+		return strconv.AppendUint(dst, uint64(t), 10)
+	case uint8:
+		return strconv.AppendUint(dst, uint64(t), 10)
+	case uint16:
+		return strconv.AppendUint(dst, uint64(t), 10)
+	case uint32:
+		return strconv.AppendUint(dst, uint64(t), 10)
+	case uint64:
+		return strconv.AppendUint(dst, t, 10)
+	case uintptr:
+		return strconv.AppendUint(dst, uint64(t), 10)
+	case float32:
+		return strconv.AppendFloat(dst, float64(t), 'g', -1, 32)
+	case float64:
+		return strconv.AppendFloat(dst, t, 'g', -1, 64)
+	case bool:
+		return strconv.AppendBool(dst, t)
+	case fmt.Formatter:
+		return fmt.Append(dst, t) // has priority over the String method
+	case error, fmt.Stringer:
+		return appendStringer(dst, t)
 	}
+	return fmt.Append(dst, val)
+}
 
-	// no links, only colors & formats
+// AppendTo appends the Value, as the String method returns it, to given
+// buffer and returns the extended buffer. For strings, byte slices,
+// integers, floats, booleans, errors and fmt.Stringers it doesn't use the
+// fmt package and doesn't allocate if the buffer has enough capacity.
+func (v Value) AppendTo(dst []byte) []byte {
+	var (
+		color = v.cc.color()
+		link  = v.cc.hyperlinksEnbaled() && v.hyperlink.isExists()
+	)
+	if link {
+		dst = v.hyperlink.appendHead(dst)
+	}
 	if color != 0 {
-		return esc + color.Nos(false) + "m" + val + clear
+		dst = v.appendSequence(dst, color, false)
+		dst = appendValue(dst, v.value)
+		dst = append(dst, clear...)
+	} else {
+		dst = appendValue(dst, v.value)
+	}
+	if link {
+		dst = append(dst, linkEndEsc...)
 	}
+	return dst
+}
 
-	// no links, no colors, no formats, just the value
-	return val
+// appendSequence appends SGR sequence of given Color of the Value, using
+// 24-bit colors with the ProfileTrueColor; the zero argument requires
+// resetting previous colors and formats
+func (v Value) appendSequence(bs []byte, color Color, zero bool) []byte {
+	var t *trueColors
+	if v.cc.profile() == ProfileTrueColor {
+		t = v.rgb
+	}
+	return t.appendSequence(bs, color, zero)
+}
+
+// isTrueColor reports whether the Value printed with 24-bit colors
+func (v Value) isTrueColor() bool {
+	if v.cc.profile() != ProfileTrueColor {
+		return false
+	}
+	var fg, bg, ul = v.rgb.active(v.Color())
+	return fg || bg || ul
+}
+
+// WriteTo implements io.WriterTo interface. It writes the Value, as the
+// String method returns it, to given io.Writer using a pooled buffer.
+func (v Value) WriteTo(w io.Writer) (n int64, err error) {
+	var buf = getBuffer()
+	*buf = v.AppendTo(*buf)
+	var wn int
+	wn, err = w.Write(*buf)
+	putBuffer(buf)
+	return int64(wn), err
+}
+
+// String implements standard fmt.Stringer interface.
+func (v Value) String() string {
+	var buf = getBuffer()
+	*buf = v.AppendTo(*buf)
+	var s = string(*buf)
+	putBuffer(buf)
+	return s
+}
+
+// Join returns given Values printed one after another, as their String
+// methods do, but colors and formats are switched between adjacent Values
+// by the Color.Transition instead of resetting them, and adjacent Values
+// with the same hyperlink share it. A Value containing escape sequences
+// is printed as is, after resetting colors and closing hyperlink.
+func Join(vals ...Value) string {
+	var buf = getBuffer()
+	*buf = appendJoin(*buf, vals)
+	var s = string(*buf)
+	putBuffer(buf)
+	return s
+}
+
+// append given Values, see the Join
+func appendJoin(bs []byte, vals []Value) []byte {
+	var (
+		cur  Color  // current colors and formats
+		seq  []byte // current sequence with 24-bit colors, if any
+		head []byte // current hyperlink head, if any
+		text []byte // text of a Value
+	)
+	var closeAll = func() {
+		if cur != 0 || seq != nil {
+			bs, cur, seq = append(bs, clear...), 0, nil
+		}
+		if head != nil {
+			bs, head = append(bs, linkEndEsc...), nil
+		}
+	}
+	for _, v := range vals {
+		if text = appendValue(text[:0], v.value); len(text) == 0 {
+			continue
+		}
+		if bytes.IndexByte(text, '\033') >= 0 {
+			closeAll()
+			bs = v.AppendTo(bs)
+			continue
+		}
+		var link []byte
+		if v.cc.hyperlinksEnbaled() && v.hyperlink.isExists() {
+			link = v.hyperlink.appendHead(nil)
+		}
+		if !bytes.Equal(link, head) {
+			if head != nil {
+				bs = append(bs, linkEndEsc...)
+			}
+			bs, head = append(bs, link...), link
+		}
+		var color = v.cc.color()
+		switch {
+		case v.isTrueColor():
+			var next = v.appendSequence(nil, color, true)
+			switch {
+			case bytes.Equal(next, seq):
+			case cur == 0 && seq == nil:
+				bs = v.appendSequence(bs, color, false) // nothing to reset
+			default:
+				bs = append(bs, next...)
+			}
+			seq = next
+		case seq != nil && color == 0:
+			bs, seq = append(bs, clear...), nil
+		case seq != nil:
+			bs, seq = v.appendSequence(bs, color, true), nil
+		default:
+			bs = append(bs, cur.Transition(color)...)
+		}
+		cur = color
+		bs = append(bs, text...)
+	}
+	closeAll()
+	return bs
 }
 
 // Color returns colors and formats of the Value.
@@ -202,13 +402,13 @@ func (v Value) Color() Color {
 
 // Reset colors, formats and links.
 func (v Value) Reset() Value {
-	v.cc, v.hyperlink = v.cc.resetColor(), nil
+	v.cc, v.hyperlink, v.rgb = v.cc.resetColor(), nil, nil
 	return v
 }
 
 // Clear colors and formats, preserving links.
 func (v Value) Clear() Value {
-	v.cc = v.cc.resetColor()
+	v.cc, v.rgb = v.cc.resetColor(), nil
 	return v
 }
 
@@ -217,14 +417,37 @@ func (v Value) Value() interface{} {
 	return v.value
 }
 
+// is the fmt.State has no flags, width and precision
+func isPlainState(s fmt.State) bool {
+	for i := 0; i < len(availFlags); i++ {
+		if s.Flag(int(availFlags[i])) {
+			return false
+		}
+	}
+	var ok bool
+	if _, ok = s.Width(); ok {
+		return false
+	}
+	_, ok = s.Precision()
+	return !ok
+}
+
 // Format implements standard fmt.Formatter interface.
 func (v Value) Format(s fmt.State, verb rune) {
+	if verb == 'v' && isPlainState(s) {
+		// fast path for the %v, the same as the String
+		var buf = getBuffer()
+		*buf = v.AppendTo(*buf)
+		s.Write(*buf) //nolint
+		putBuffer(buf)
+		return
+	}
 	if !v.cc.hyperlinksEnbaled() {
-		fmt.Fprintf(s, coloredFormat(v.Color(), s, verb), v.value)
+		fmt.Fprintf(s, coloredFormat(v, s, verb), v.value)
 		return
 	}
 	v.hyperlink.writeHead(s)
-	fmt.Fprintf(s, coloredFormat(v.Color(), s, verb), v.value)
+	fmt.Fprintf(s, coloredFormat(v, s, verb), v.value)
 	v.hyperlink.writeTail(s)
 }
 
@@ -334,6 +557,81 @@ func (v Value) Overlined() Value {
 	return v
 }
 
+// Curly underline, not widely supported (4:3).
+func (v Value) CurlyUnderline() Value {
+	v.cc = colorConfig(v.cc.color().CurlyUnderline()) | v.cc.resetColor()
+	return v
+}
+
+// Dotted underline, not widely supported (4:4).
+func (v Value) DottedUnderline() Value {
+	v.cc = colorConfig(v.cc.color().DottedUnderline()) | v.cc.resetColor()
+	return v
+}
+
+// Dashed underline, not widely supported (4:5).
+func (v Value) DashedUnderline() Value {
+	v.cc = colorConfig(v.cc.color().DashedUnderline()) | v.cc.resetColor()
+	return v
+}
+
+// Proportional spacing, rarely supported (26).
+func (v Value) ProportionalSpacing() Value {
+	v.cc = colorConfig(v.cc.color().ProportionalSpacing()) | v.cc.resetColor()
+	return v
+}
+
+// IdeogramUnderline or right side line, rarely supported (60).
+func (v Value) IdeogramUnderline() Value {
+	v.cc = colorConfig(v.cc.color().IdeogramUnderline()) | v.cc.resetColor()
+	return v
+}
+
+// IdeogramDoubleUnderline or double line on the right side, rarely
+// supported (61).
+func (v Value) IdeogramDoubleUnderline() Value {
+	v.cc = colorConfig(v.cc.color().IdeogramDoubleUnderline()) | v.cc.resetColor()
+	return v
+}
+
+// IdeogramOverline or left side line, rarely supported (62).
+func (v Value) IdeogramOverline() Value {
+	v.cc = colorConfig(v.cc.color().IdeogramOverline()) | v.cc.resetColor()
+	return v
+}
+
+// IdeogramDoubleOverline or double line on the left side, rarely
+// supported (63).
+func (v Value) IdeogramDoubleOverline() Value {
+	v.cc = colorConfig(v.cc.color().IdeogramDoubleOverline()) | v.cc.resetColor()
+	return v
+}
+
+// IdeogramStress marking, rarely supported (64).
+func (v Value) IdeogramStress() Value {
+	v.cc = colorConfig(v.cc.color().IdeogramStress()) | v.cc.resetColor()
+	return v
+}
+
+// Superscript, reset the Subscript, rarely supported (73).
+func (v Value) Superscript() Value {
+	v.cc = colorConfig(v.cc.color().Superscript()) | v.cc.resetColor()
+	return v
+}
+
+// Subscript, reset the Superscript, rarely supported (74).
+func (v Value) Subscript() Value {
+	v.cc = colorConfig(v.cc.color().Subscript()) | v.cc.resetColor()
+	return v
+}
+
+// Font sets alternative font from 1 to 9 (11-19), rarely supported. The
+// zero is the primary font (10).
+func (v Value) Font(n FontIndex) Value {
+	v.cc = colorConfig(v.cc.color().Font(n)) | v.cc.resetColor()
+	return v
+}
+
 // Foreground colors.
 //
 // Black foreground color (30).
@@ -444,12 +742,22 @@ func (v Value) BrightWhite() Value {
 //	232-255:  grayscale from black to white in 24 steps
 func (v Value) Index(n ColorIndex) Value {
 	v.cc = colorConfig(v.cc.color().Index(n)) | v.cc.resetColor()
+	v.rgb = v.rgb.noFg()
 	return v
 }
 
 // Gray from 0 to 24.
 func (v Value) Gray(n GrayIndex) Value {
 	v.cc = colorConfig(v.cc.color().Gray(n)) | v.cc.resetColor()
+	v.rgb = v.rgb.noFg()
+	return v
+}
+
+// TrueColor sets 24-bit foreground color (38;2;r;g;b), used with the
+// ProfileTrueColor. Other profiles use nearest 8-bit color, see RGB.Index.
+func (v Value) TrueColor(rgb RGB) Value {
+	v.cc = colorConfig(v.cc.color().TrueColor(rgb)) | v.cc.resetColor()
+	v.rgb = v.rgb.withFg(rgb)
 	return v
 }
 
@@ -563,12 +871,160 @@ func (v Value) BgBrightWhite() Value {
 //	232-255:  grayscale from black to white in 24 steps
 func (v Value) BgIndex(n ColorIndex) Value {
 	v.cc = colorConfig(v.cc.color().BgIndex(n)) | v.cc.resetColor()
+	v.rgb = v.rgb.noBg()
 	return v
 }
 
 // BgGray from 0 to 24.
 func (v Value) BgGray(n GrayIndex) Value {
 	v.cc = colorConfig(v.cc.color().BgGray(n)) | v.cc.resetColor()
+	v.rgb = v.rgb.noBg()
+	return v
+}
+
+// BgTrueColor sets 24-bit background color (48;2;r;g;b). See TrueColor.
+func (v Value) BgTrueColor(rgb RGB) Value {
+	v.cc = colorConfig(v.cc.color().BgTrueColor(rgb)) | v.cc.resetColor()
+	v.rgb = v.rgb.withBg(rgb)
+	return v
+}
+
+// Underline color.
+//
+// UnderlineIndex sets underline color, 8-bit pre-defined color from 0 to
+// 255 (58;5;n), not widely supported. See Index for details.
+func (v Value) UnderlineIndex(n ColorIndex) Value {
+	v.cc = colorConfig(v.cc.color().UnderlineIndex(n)) | v.cc.resetColor()
+	v.rgb = v.rgb.noUl()
+	return v
+}
+
+// UnderlineGray sets gray underline color from 0 to 23.
+func (v Value) UnderlineGray(n GrayIndex) Value {
+	v.cc = colorConfig(v.cc.color().UnderlineGray(n)) | v.cc.resetColor()
+	v.rgb = v.rgb.noUl()
+	return v
+}
+
+// Removal.
+//
+// NoBold removes Bold format.
+func (v Value) NoBold() Value {
+	v.cc = colorConfig(v.cc.color().NoBold()) | v.cc.resetColor()
+	return v
+}
+
+// NoFaint removes Faint format.
+func (v Value) NoFaint() Value {
+	v.cc = colorConfig(v.cc.color().NoFaint()) | v.cc.resetColor()
+	return v
+}
+
+// NoItalic removes Italic format.
+func (v Value) NoItalic() Value {
+	v.cc = colorConfig(v.cc.color().NoItalic()) | v.cc.resetColor()
+	return v
+}
+
+// NoUnderline removes Underline and DoublyUnderline formats.
+func (v Value) NoUnderline() Value {
+	v.cc = colorConfig(v.cc.color().NoUnderline()) | v.cc.resetColor()
+	return v
+}
+
+// NoBlink removes SlowBlink and RapidBlink formats.
+func (v Value) NoBlink() Value {
+	v.cc = colorConfig(v.cc.color().NoBlink()) | v.cc.resetColor()
+	return v
+}
+
+// NoReverse removes Reverse format.
+func (v Value) NoReverse() Value {
+	v.cc = colorConfig(v.cc.color().NoReverse()) | v.cc.resetColor()
+	return v
+}
+
+// NoConceal removes Conceal format.
+func (v Value) NoConceal() Value {
+	v.cc = colorConfig(v.cc.color().NoConceal()) | v.cc.resetColor()
+	return v
+}
+
+// NoCrossedOut removes CrossedOut format.
+func (v Value) NoCrossedOut() Value {
+	v.cc = colorConfig(v.cc.color().NoCrossedOut()) | v.cc.resetColor()
+	return v
+}
+
+// NoFraktur removes Fraktur format.
+func (v Value) NoFraktur() Value {
+	v.cc = colorConfig(v.cc.color().NoFraktur()) | v.cc.resetColor()
+	return v
+}
+
+// NoFramed removes Framed format.
+func (v Value) NoFramed() Value {
+	v.cc = colorConfig(v.cc.color().NoFramed()) | v.cc.resetColor()
+	return v
+}
+
+// NoEncircled removes Encircled format.
+func (v Value) NoEncircled() Value {
+	v.cc = colorConfig(v.cc.color().NoEncircled()) | v.cc.resetColor()
+	return v
+}
+
+// NoOverlined removes Overlined format.
+func (v Value) NoOverlined() Value {
+	v.cc = colorConfig(v.cc.color().NoOverlined()) | v.cc.resetColor()
+	return v
+}
+
+// NoForeground removes foreground color.
+func (v Value) NoForeground() Value {
+	v.cc = colorConfig(v.cc.color().NoForeground()) | v.cc.resetColor()
+	return v
+}
+
+// NoBackground removes background color.
+func (v Value) NoBackground() Value {
+	v.cc = colorConfig(v.cc.color().NoBackground()) | v.cc.resetColor()
+	return v
+}
+
+// NoUnderlineColor removes underline color.
+func (v Value) NoUnderlineColor() Value {
+	v.cc = colorConfig(v.cc.color().NoUnderlineColor()) | v.cc.resetColor()
+	return v
+}
+
+// NoProportionalSpacing removes ProportionalSpacing format.
+func (v Value) NoProportionalSpacing() Value {
+	v.cc = colorConfig(v.cc.color().NoProportionalSpacing()) | v.cc.resetColor()
+	return v
+}
+
+// NoIdeograms removes all ideogram formats.
+func (v Value) NoIdeograms() Value {
+	v.cc = colorConfig(v.cc.color().NoIdeograms()) | v.cc.resetColor()
+	return v
+}
+
+// NoScript removes Superscript and Subscript formats.
+func (v Value) NoScript() Value {
+	v.cc = colorConfig(v.cc.color().NoScript()) | v.cc.resetColor()
+	return v
+}
+
+// NoFont removes alternative font, using the primary one.
+func (v Value) NoFont() Value {
+	v.cc = colorConfig(v.cc.color().NoFont()) | v.cc.resetColor()
+	return v
+}
+
+// Without removes formats and colors of given Color. See Color.Without.
+func (v Value) Without(color Color) Value {
+	v.cc = colorConfig(v.cc.color().Without(color)) | v.cc.resetColor()
 	return v
 }
 
@@ -577,10 +1033,26 @@ func (v Value) BgGray(n GrayIndex) Value {
 // Colorize removes existing colors and formats of the argument and applies
 // given.
 func (v Value) Colorize(color Color) Value {
-	v.cc = colorConfig(color) | v.cc.resetColor()
+	v.cc, v.rgb = colorConfig(color)&maskColor|v.cc.resetColor(), nil
 	return v
 }
 
+// Merge colors and formats of given Style over the Value ones, like the
+// Color.Merge does, including 24-bit colors. Hyperlink template and
+// colorizer of the Style are not used.
+func (v Value) Merge(s Style) Value {
+	var color = Color(v.cc & maskColor).Merge(s.color)
+	v.cc = colorConfig(color)&maskColor | v.cc.resetColor()
+	v.rgb = v.rgb.merge(s.rgb, s.color)
+	return v
+}
+
+// Style returns Style with colors and formats of the Value, including
+// 24-bit colors. The Style has no hyperlink template.
+func (v Value) Style() Style {
+	return Style{color: v.Color(), rgb: v.rgb}
+}
+
 // Hyperlinks feature
 //
 // Hyperlink with given target and parameters. If hyperlinks feature is
@@ -36,7 +36,9 @@
 package aurora
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -79,20 +81,20 @@ func TestValue_Reset(t *testing.T) {
 	// colorized
 	var au = New()
 	assert.Equal(t, Value{
-		cc:    au.cc,
+		cc:    au.load().cc,
 		value: "x",
 	}, au.Red("x").BgBlack().Reset())
 	// clear
 	au = New(WithColors(false))
 	assert.Equal(t, Value{
-		cc:    au.cc,
+		cc:    au.load().cc,
 		value: "x",
 	}, au.Red("x").BgBlack().Reset())
 	// a hyperlink
 	au = New()
 	assert.Equal(t,
 		Value{
-			cc:    au.cc,
+			cc:    au.load().cc,
 			value: "x",
 		}, au.Red("x").
 			BgBlack().
@@ -104,20 +106,20 @@ func TestValue_Clear(t *testing.T) {
 	// colorized
 	var au = New()
 	assert.Equal(t, Value{
-		cc:    au.cc,
+		cc:    au.load().cc,
 		value: "x",
 	}, au.Red("x").BgBlack().Clear())
 	// clear
 	au = New(WithColors(false))
 	assert.Equal(t, Value{
-		cc:    au.cc,
+		cc:    au.load().cc,
 		value: "x",
 	}, au.Red("x").BgBlack().Clear())
 	// a hyperlink
 	au = New()
 	assert.EqualValues(t,
 		Value{
-			cc:    au.cc,
+			cc:    au.load().cc,
 			value: "x",
 			hyperlink: &hyperlink{
 				target: "http://example.com/path",
@@ -330,11 +332,67 @@ func TestValue_colors(t *testing.T) {
 	test("BgBrightWhite", au.Reset("x").BgBrightWhite(), BrightBg|WhiteBg)
 	test("BgIndex", au.Reset("x").BgIndex(187), Color(187)<<shiftBg|flagBg)
 	test("BgGray", au.Reset("x").BgGray(15), Color(232+15)<<shiftBg|flagBg)
+	test("TrueColor", au.Reset("x").TrueColor(0xff8800),
+		Color(208)<<shiftFg|flagFg)
+	test("BgTrueColor", au.Reset("x").BgTrueColor(0xff8800),
+		Color(208)<<shiftBg|flagBg)
 	test("Colorize", au.Reset("x").Colorize(RedFg|BlueBg|BrightBg|BoldFm),
 		RedFg|BlueBg|BrightBg|BoldFm)
 	// overflow
 	test("Gray", au.Reset("x").Gray(151), Color(232+23)<<shiftFg|flagFg)
 	test("BgGray", au.Reset("x").BgGray(115), Color(232+23)<<shiftBg|flagBg)
+	// underline
+	test("CurlyUnderline", au.Underline("x").CurlyUnderline(), CurlyUnderlineFm)
+	test("DottedUnderline", au.Underline("x").DottedUnderline(),
+		DottedUnderlineFm)
+	test("DashedUnderline", au.Underline("x").DashedUnderline(),
+		DashedUnderlineFm)
+	test("UnderlineIndex", au.Reset("x").UnderlineIndex(187),
+		Color(187)<<shiftUl|flagUl)
+	test("UnderlineGray", au.Reset("x").UnderlineGray(15),
+		Color(232+15)<<shiftUl|flagUl)
+	test("NoUnderlineColor", au.Bold("x").UnderlineGray(15).NoUnderlineColor(),
+		BoldFm)
+	// other ECMA-48 formats
+	test("ProportionalSpacing", au.Reset("x").ProportionalSpacing(),
+		ProportionalSpacingFm)
+	test("Ideograms", au.Reset("x").IdeogramUnderline().
+		IdeogramDoubleUnderline().IdeogramOverline().IdeogramDoubleOverline().
+		IdeogramStress(), maskIdeogram)
+	test("Superscript", au.Reset("x").Subscript().Superscript(), SuperscriptFm)
+	test("Subscript", au.Reset("x").Superscript().Subscript(), SubscriptFm)
+	test("Font", au.Reset("x").Font(2), Color(2)<<shiftFont)
+	test("NoProportionalSpacing", au.Bold("x").ProportionalSpacing().
+		NoProportionalSpacing(), BoldFm)
+	test("NoIdeograms", au.Bold("x").IdeogramStress().NoIdeograms(), BoldFm)
+	test("NoScript", au.Bold("x").Subscript().NoScript(), BoldFm)
+	test("NoFont", au.Bold("x").Font(2).NoFont(), BoldFm)
+}
+
+func TestValue_removal(t *testing.T) {
+	var (
+		au = New()
+		v  = au.Bold("x").Italic().Underline().DoublyUnderline().
+			SlowBlink().Red().BgBlue()
+	)
+	assert.Equal(t, ItalicFm|DoublyUnderlineFm|SlowBlinkFm|
+		RedFg|BlueBg, v.NoBold().Color())
+	assert.Equal(t, BoldFm|SlowBlinkFm|RedFg|BlueBg,
+		v.NoItalic().NoUnderline().Color())
+	assert.Equal(t, BoldFm|ItalicFm|DoublyUnderlineFm|RedFg|
+		BlueBg, v.NoBlink().Color())
+	assert.Equal(t, BoldFm|ItalicFm|DoublyUnderlineFm|
+		SlowBlinkFm, v.NoForeground().NoBackground().Color())
+	assert.Equal(t, ItalicFm|DoublyUnderlineFm|SlowBlinkFm|
+		BlueBg, v.Without(BoldFm|BlackFg).Color())
+	assert.Equal(t, "\033[1;32mx\033[0m",
+		au.Bold("x").Italic().Green().NoItalic().String())
+	assert.Equal(t, "\033[1mx\033[0m",
+		au.Bold("x").Red().NoForeground().String())
+	// disabled colors
+	au = New(WithColors(false))
+	assert.Equal(t, Color(0), au.Bold("x").NoItalic().Color())
+	assert.Equal(t, "x", au.Bold("x").Without(BoldFm).String())
 }
 
 func TestValue_hyperlinks(t *testing.T) {
@@ -375,3 +433,116 @@ func TestValue_hyperlinks(t *testing.T) {
 	assert.Equal(t, "", val.HyperlinkTarget())
 	assert.Nil(t, val.HyperlinkParams())
 }
+
+type testStringer struct{ s string }
+
+func (t *testStringer) String() string { return t.s }
+
+func TestValue_AppendTo(t *testing.T) {
+	var (
+		au   = New()
+		nilS *testStringer
+		vals = []interface{}{
+			"x", []byte("hi"), 0, -10, int8(-8), int16(16), int32(-32),
+			int64(64), uint(1), uint8(8), uint16(16), uint32(32), uint64(64),
+			uintptr(10), float32(3.14), 2.7834, 1e21, 1e-7, true, false,
+			fmt.Errorf("error"), &testStringer{"stringer"}, nilS, nil,
+			struct{ X int }{1},
+		}
+	)
+	for _, val := range vals {
+		var want = fmt.Sprint(val)
+		assert.Equal(t, want, string(au.Reset(val).AppendTo(nil)), "%T", val)
+		assert.Equal(t, "\033[31m"+want+"\033[0m",
+			string(au.Red(val).AppendTo(nil)), "%T", val)
+	}
+	// prefix
+	assert.Equal(t, "prefix: \033[1mx\033[0m",
+		string(au.Bold("x").AppendTo([]byte("prefix: "))))
+	// hyperlink
+	assert.Equal(t, au.Red("x").Hyperlink("http://example.com").String(),
+		string(au.Red("x").Hyperlink("http://example.com").AppendTo(nil)))
+	assert.Equal(t, "\033]8;;http://example.com\033\\\033[31mx\033[0m"+
+		"\033]8;;\033\\",
+		string(au.Red("x").Hyperlink("http://example.com").AppendTo(nil)))
+	// no colors, no links
+	au = New(WithColors(false), WithHyperlinks(false))
+	assert.Equal(t, "x", string(au.Red("x").Bold().AppendTo(nil)))
+	// allocations
+	au = New()
+	var (
+		buf = make([]byte, 0, 128)
+		red = au.Red("x").BgIndex(100).Bold()
+		num = au.Red(3.14)
+		lnk = au.Red("x").Hyperlink("http://example.com")
+	)
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		buf = red.AppendTo(buf[:0])
+		buf = num.AppendTo(buf[:0])
+		buf = lnk.AppendTo(buf[:0])
+	}))
+}
+
+func TestValue_WriteTo(t *testing.T) {
+	var (
+		au  = New()
+		buf bytes.Buffer
+		val = au.Red("x").Bold()
+	)
+	var n, err = val.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Equal(t, val.String(), buf.String())
+}
+
+func TestValue_Format_fast(t *testing.T) {
+	var au = New()
+	assert.Equal(t, "\033[31m3.14\033[0m", fmt.Sprintf("%v", au.Red(3.14)))
+	assert.Equal(t, "\033[31m{X:1}\033[0m",
+		fmt.Sprintf("%+v", au.Red(struct{ X int }{1})))
+	assert.Equal(t, "\033[31m  3.14\033[0m", fmt.Sprintf("%6v", au.Red(3.14)))
+	assert.Equal(t, "\033]8;;http://example.com\033\\\033[31mx\033[0m"+
+		"\033]8;;\033\\",
+		fmt.Sprintf("%v", au.Red("x").Hyperlink("http://example.com")))
+}
+
+func TestJoin(t *testing.T) {
+	var (
+		au = New()
+		tc = New(WithProfile(ProfileTrueColor))
+	)
+	for _, tt := range []struct {
+		name string
+		vals []Value
+		want string
+	}{
+		{"empty", nil, ""},
+		{"plain", []Value{au.Reset("a"), au.Reset(""), au.Reset(1)}, "a1"},
+		{"same", []Value{au.Bold("a"), au.Bold("b")}, "\033[1mab\033[0m"},
+		{"transition", []Value{au.Bold(au.Red("a")), au.Red("b"),
+			au.Reset("c"), au.Blue("d")},
+			"\033[1;31ma\033[22mb\033[0mc\033[34md\033[0m"},
+		{"hyperlink", []Value{au.Red("a").Hyperlink("http://x/"),
+			au.Blue("b").Hyperlink("http://x/"), au.Blue("c")},
+			"\033]8;;http://x/\033\\\033[31ma\033[34mb" +
+				"\033]8;;\033\\c\033[0m"},
+		{"true color", []Value{tc.TrueColor(0xff8800, "a"),
+			tc.TrueColor(0xff8800, "b"), tc.Red("c"),
+			tc.TrueColor(0x0000ff, "d")},
+			"\033[38;2;255;136;0mab\033[0;31mc" +
+				"\033[0;38;2;0;0;255md\033[0m"},
+		{"escapes", []Value{au.Red("a"), au.Reset(au.Bold("b").String()),
+			au.Red("c")},
+			"\033[31ma\033[0m\033[1mb\033[0m\033[31mc\033[0m"},
+		{"no colors", []Value{New(WithColors(false)).Red("a"), au.Reset("b")},
+			"ab"},
+	} {
+		var got = Join(tt.vals...)
+		assert.Equal(t, tt.want, got, tt.name)
+		var concat strings.Builder
+		for _, v := range tt.vals {
+			concat.WriteString(v.String())
+		}
+		assert.Equal(t, Spans(concat.String()), Spans(got), tt.name)
+	}
+}
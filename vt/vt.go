@@ -0,0 +1,401 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+// Package vt implements in-memory terminal screen for tests. A Screen
+// interprets text, SGR and OSC 8 hyperlink sequences, cursor movements and
+// erase sequences into a grid of cells with runes, colors and hyperlinks.
+//
+//	var scr = vt.New(80, 24)
+//	fmt.Fprint(scr, aurora.Bold(aurora.Red("error")))
+//	var c = scr.Cell(0, 0) // {Rune: 'e', Color: aurora.BoldFm | aurora.RedFg}
+//
+// The Screen emulates output of a tty with the ONLCR mode, thus the "\n"
+// moves cursor to beginning of next line. Unsupported sequences are
+// ignored.
+package vt
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/logrusorgru/aurora/v4"
+)
+
+// A Cell of a Screen. 24-bit colors of the Cell are replaced with
+// nearest 8-bit ones, see the aurora.Color.ApplySGR.
+type Cell struct {
+	Rune  rune         // rune, zero for empty cell, -1 for second half of wide rune
+	Color aurora.Color // colors and formats of the cell
+	Link  string       // hyperlink target, if any
+}
+
+// parser states
+const (
+	stateGround = iota // text
+	stateEsc           // after ESC
+	stateCSI           // in CSI sequence
+	stateStr           // in OSC, DCS, SOS, PM or APC sequence
+	stateStrEsc        // ESC in OSC, DCS, SOS, PM or APC sequence
+)
+
+// tab stop width
+const tabWidth = 8
+
+// A Screen is an in-memory terminal. The Screen is not safe for
+// concurrent use.
+type Screen struct {
+	width, height int
+	cells         [][]Cell
+
+	row, col   int  // cursor position
+	wrapNext   bool // the cursor is past the last column
+	saved      [2]int
+	color      aurora.Color
+	link       string
+	scrolled   int // number of lines scrolled out
+	state      int
+	seq        []byte // current escape sequence
+	incomplete []byte // incomplete UTF-8 rune
+}
+
+// New Screen of given size.
+func New(width, height int) (s *Screen) {
+	if width <= 0 || height <= 0 {
+		panic("vt: non-positive screen size")
+	}
+	s = &Screen{width: width, height: height}
+	s.cells = make([][]Cell, height)
+	for i := range s.cells {
+		s.cells[i] = make([]Cell, width)
+	}
+	return
+}
+
+// Size of the Screen.
+func (s *Screen) Size() (width, height int) {
+	return s.width, s.height
+}
+
+// Cursor position.
+func (s *Screen) Cursor() (row, col int) {
+	return s.row, s.col
+}
+
+// Scrolled returns number of lines scrolled out of the Screen.
+func (s *Screen) Scrolled() int {
+	return s.scrolled
+}
+
+// Cell at given position. It panics if the position is out of the
+// Screen.
+func (s *Screen) Cell(row, col int) Cell {
+	return s.cells[row][col]
+}
+
+// Line returns visible text of given line without trailing spaces. Empty
+// cells are spaces.
+func (s *Screen) Line(row int) string {
+	var b strings.Builder
+	for _, c := range s.cells[row] {
+		switch {
+		case c.Rune == 0:
+			b.WriteByte(' ')
+		case c.Rune > 0:
+			b.WriteRune(c.Rune)
+		} // negative is continuation of a wide rune
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// String returns visible text of the Screen without trailing empty lines.
+func (s *Screen) String() string {
+	var lines = make([]string, s.height)
+	for i := range lines {
+		lines[i] = s.Line(i)
+	}
+	var n = len(lines)
+	for n > 0 && lines[n-1] == "" {
+		n--
+	}
+	return strings.Join(lines[:n], "\n")
+}
+
+// Write interprets given bytes. Escape sequences and runes can be split
+// between writes. It never returns an error.
+func (s *Screen) Write(p []byte) (n int, err error) {
+	for _, b := range p {
+		s.byte(b)
+	}
+	return len(p), nil
+}
+
+func (s *Screen) byte(b byte) {
+	switch s.state {
+	case stateGround:
+		s.ground(b)
+	case stateEsc:
+		s.seq = append(s.seq, b)
+		switch {
+		case b == '[':
+			s.state = stateCSI
+		case b == ']', b == 'P', b == 'X', b == '^', b == '_':
+			s.state = stateStr
+		case 0x20 <= b && b <= 0x2f:
+			// intermediate byte
+		default:
+			s.state = stateGround
+			s.escape(string(s.seq))
+		}
+	case stateCSI:
+		s.seq = append(s.seq, b)
+		if 0x40 <= b && b <= 0x7e {
+			s.state = stateGround
+			s.csi(string(s.seq[2:len(s.seq)-1]), b)
+		}
+	case stateStr:
+		switch b {
+		case '\a':
+			s.state = stateGround
+			s.osc(string(s.seq[2:]))
+		case '\033':
+			s.state = stateStrEsc
+		default:
+			s.seq = append(s.seq, b)
+		}
+	case stateStrEsc:
+		if b == '\\' {
+			s.state = stateGround
+			s.osc(string(s.seq[2:]))
+		} else {
+			s.state = stateStr
+			s.seq = append(s.seq, '\033', b)
+		}
+	}
+}
+
+func (s *Screen) ground(b byte) {
+	if len(s.incomplete) > 0 || b >= utf8.RuneSelf {
+		s.incomplete = append(s.incomplete, b)
+		if !utf8.FullRune(s.incomplete) {
+			return
+		}
+		var r, _ = utf8.DecodeRune(s.incomplete)
+		s.incomplete = s.incomplete[:0]
+		s.put(r)
+		return
+	}
+	switch b {
+	case '\033':
+		s.state = stateEsc
+		s.seq = append(s.seq[:0], b)
+	case '\n', '\v', '\f':
+		s.col = 0
+		s.lineFeed()
+	case '\r':
+		s.col, s.wrapNext = 0, false
+	case '\b':
+		if s.col > 0 {
+			s.col--
+		}
+		s.wrapNext = false
+	case '\t':
+		s.col = (s.col/tabWidth + 1) * tabWidth
+		if s.col >= s.width {
+			s.col = s.width - 1
+		}
+		s.wrapNext = false
+	default:
+		if b >= 0x20 && b != 0x7f {
+			s.put(rune(b))
+		}
+	}
+}
+
+// lineFeed moves cursor down scrolling the Screen if needed
+func (s *Screen) lineFeed() {
+	s.wrapNext = false
+	if s.row < s.height-1 {
+		s.row++
+		return
+	}
+	var top = s.cells[0]
+	copy(s.cells, s.cells[1:])
+	for i := range top {
+		top[i] = Cell{}
+	}
+	s.cells[s.height-1] = top
+	s.scrolled++
+}
+
+// put given rune at the cursor
+func (s *Screen) put(r rune) {
+	var w = aurora.RuneWidth(r)
+	if w == 0 || w > s.width {
+		return // control characters, combining marks and too wide runes
+	}
+	if s.wrapNext || s.col+w > s.width {
+		s.col = 0
+		s.lineFeed()
+	}
+	var line = s.cells[s.row]
+	line[s.col] = Cell{Rune: r, Color: s.color, Link: s.link}
+	if w == 2 {
+		line[s.col+1] = Cell{Rune: -1, Color: s.color, Link: s.link}
+	}
+	if s.col += w; s.col >= s.width {
+		s.col, s.wrapNext = s.width-1, true
+	}
+}
+
+// escape handles two-byte sequences
+func (s *Screen) escape(seq string) {
+	switch seq {
+	case "\0337": // save cursor
+		s.saved = [2]int{s.row, s.col}
+	case "\0338": // restore cursor
+		s.row, s.col, s.wrapNext = s.saved[0], s.saved[1], false
+	case "\033c": // reset
+		*s = *New(s.width, s.height)
+	}
+}
+
+// osc handles OSC sequences
+func (s *Screen) osc(body string) {
+	if !strings.HasPrefix(body, "8;") {
+		return
+	}
+	body = body[2:]
+	if i := strings.IndexByte(body, ';'); i >= 0 {
+		s.link = body[i+1:]
+	}
+}
+
+// parse numeric parameters of CSI sequence, where missing or zero ones
+// are replaced with given default
+func params(p string, def int, n int) (ps []int) {
+	var fields = strings.Split(p, ";")
+	for i := 0; i < n; i++ {
+		var v = def
+		if i < len(fields) {
+			if x, err := strconv.Atoi(fields[i]); err == nil && x > 0 {
+				v = x
+			}
+		}
+		ps = append(ps, v)
+	}
+	return
+}
+
+func clamp(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func (s *Screen) erase(row, from, to int) {
+	var line = s.cells[row]
+	for i := clamp(from, 0, s.width); i < clamp(to, 0, s.width); i++ {
+		line[i] = Cell{}
+	}
+}
+
+// csi handles CSI sequences
+func (s *Screen) csi(p string, final byte) {
+	if strings.HasPrefix(p, "?") || strings.HasPrefix(p, ">") {
+		return // private modes
+	}
+	if final != 'm' {
+		s.wrapNext = false
+	}
+	switch final {
+	case 'm':
+		s.color = s.color.ApplySGR(p)
+	case 'A': // up
+		s.row = clamp(s.row-params(p, 1, 1)[0], 0, s.height-1)
+	case 'B': // down
+		s.row = clamp(s.row+params(p, 1, 1)[0], 0, s.height-1)
+	case 'C': // forward
+		s.col = clamp(s.col+params(p, 1, 1)[0], 0, s.width-1)
+	case 'D': // back
+		s.col = clamp(s.col-params(p, 1, 1)[0], 0, s.width-1)
+	case 'E': // next line
+		s.row, s.col = clamp(s.row+params(p, 1, 1)[0], 0, s.height-1), 0
+	case 'F': // previous line
+		s.row, s.col = clamp(s.row-params(p, 1, 1)[0], 0, s.height-1), 0
+	case 'G': // column
+		s.col = clamp(params(p, 1, 1)[0]-1, 0, s.width-1)
+	case 'd': // row
+		s.row = clamp(params(p, 1, 1)[0]-1, 0, s.height-1)
+	case 'H', 'f': // position
+		var ps = params(p, 1, 2)
+		s.row = clamp(ps[0]-1, 0, s.height-1)
+		s.col = clamp(ps[1]-1, 0, s.width-1)
+	case 'J': // erase display
+		switch p {
+		case "", "0":
+			s.erase(s.row, s.col, s.width)
+			for i := s.row + 1; i < s.height; i++ {
+				s.erase(i, 0, s.width)
+			}
+		case "1":
+			for i := 0; i < s.row; i++ {
+				s.erase(i, 0, s.width)
+			}
+			s.erase(s.row, 0, s.col+1)
+		case "2", "3":
+			for i := 0; i < s.height; i++ {
+				s.erase(i, 0, s.width)
+			}
+		}
+	case 'K': // erase line
+		switch p {
+		case "", "0":
+			s.erase(s.row, s.col, s.width)
+		case "1":
+			s.erase(s.row, 0, s.col+1)
+		case "2":
+			s.erase(s.row, 0, s.width)
+		}
+	case 's': // save cursor
+		s.saved = [2]int{s.row, s.col}
+	case 'u': // restore cursor
+		s.row, s.col = s.saved[0], s.saved[1]
+	}
+}
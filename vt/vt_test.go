@@ -0,0 +1,219 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package vt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/logrusorgru/aurora/v4/live"
+	"github.com/logrusorgru/aurora/v4/table"
+)
+
+// colors and hyperlinks are enabled regardless of output
+var au = aurora.New()
+
+func TestNew(t *testing.T) {
+	var s = New(10, 3)
+	var w, h = s.Size()
+	assert.Equal(t, 10, w)
+	assert.Equal(t, 3, h)
+	assert.Equal(t, "", s.String())
+	assert.Panics(t, func() { New(0, 1) })
+}
+
+func TestScreen_Write_text(t *testing.T) {
+	var s = New(5, 3)
+	var n, err = fmt.Fprint(s, "ab\ncdefgh\rX\tY")
+	require.NoError(t, err)
+	assert.Equal(t, 13, n)
+	assert.Equal(t, "ab\ncdefg\nX   Y", s.String())
+	var row, col = s.Cursor()
+	assert.Equal(t, 2, row)
+	assert.Equal(t, 4, col)
+	// scroll
+	fmt.Fprint(s, "\nlast")
+	assert.Equal(t, "cdefg\nX   Y\nlast", s.String())
+	assert.Equal(t, 1, s.Scrolled())
+	// backspace
+	fmt.Fprint(s, "\b\bZ")
+	assert.Equal(t, "laZt", s.Line(2))
+}
+
+func TestScreen_Write_wrap(t *testing.T) {
+	var s = New(3, 2)
+	fmt.Fprint(s, "abc")
+	var row, col = s.Cursor()
+	assert.Equal(t, 0, row)
+	assert.Equal(t, 2, col)
+	fmt.Fprint(s, "d")
+	assert.Equal(t, "abc\nd", s.String())
+	// wide rune doesn't fit
+	s = New(3, 2)
+	fmt.Fprint(s, "ab世")
+	assert.Equal(t, "ab\n世", s.String())
+	assert.Equal(t, '世', s.Cell(1, 0).Rune)
+	assert.Equal(t, rune(-1), s.Cell(1, 1).Rune)
+	// wide rune wider than the Screen is dropped
+	s = New(1, 3)
+	fmt.Fprint(s, "a世b")
+	assert.Equal(t, "a\nb", s.String())
+	// tab doesn't wrap a full line
+	s = New(3, 2)
+	fmt.Fprint(s, "abc\td")
+	assert.Equal(t, "abd", s.String())
+}
+
+func TestScreen_Write_split(t *testing.T) {
+	var s = New(10, 1)
+	var data = []byte(au.Hyperlink(au.Red("世界"), "http://x/").String())
+	for _, b := range data {
+		s.Write([]byte{b})
+	}
+	assert.Equal(t, Cell{Rune: '世', Color: aurora.RedFg, Link: "http://x/"},
+		s.Cell(0, 0))
+	assert.Equal(t, Cell{Rune: '界', Color: aurora.RedFg, Link: "http://x/"},
+		s.Cell(0, 2))
+	fmt.Fprint(s, "!")
+	assert.Equal(t, Cell{Rune: '!'}, s.Cell(0, 4))
+}
+
+func TestScreen_Write_styles(t *testing.T) {
+	var s = New(20, 1)
+	fmt.Fprint(s, au.Sprintf(au.Blue("a %s c"), au.Bold(au.Red("b"))))
+	assert.Equal(t, Cell{Rune: 'a', Color: aurora.BlueFg}, s.Cell(0, 0))
+	assert.Equal(t, Cell{Rune: ' ', Color: aurora.BlueFg}, s.Cell(0, 1))
+	assert.Equal(t, Cell{Rune: 'b', Color: aurora.BoldFm | aurora.RedFg},
+		s.Cell(0, 2))
+	assert.Equal(t, Cell{Rune: 'c', Color: aurora.BlueFg}, s.Cell(0, 4))
+	fmt.Fprint(s, "\033]8;id=1;http://y/\ad\033]8;;\ae")
+	assert.Equal(t, Cell{Rune: 'd', Link: "http://y/"}, s.Cell(0, 5))
+	assert.Equal(t, Cell{Rune: 'e'}, s.Cell(0, 6))
+}
+
+func TestScreen_Write_cursor(t *testing.T) {
+	var s = New(10, 5)
+	fmt.Fprint(s, "\033[3;4HX")      // position
+	fmt.Fprint(s, "\033[2AY")        // up
+	fmt.Fprint(s, "\033[BZ")         // down
+	fmt.Fprint(s, "\033[3CW")        // forward
+	fmt.Fprint(s, "\033[10DV")       // back
+	fmt.Fprint(s, "\033[2EU")        // next line
+	fmt.Fprint(s, "\033[FT\033[8GS") // previous line, column
+	fmt.Fprint(s, "\033[5dR")        // row
+	fmt.Fprint(s, "\033[100;100HQ")  // clamped
+	assert.Equal(t, ""+
+		"    Y\n"+
+		"V    Z   W\n"+
+		"T  X   S\n"+
+		"U\n"+
+		"        RQ", s.String())
+	// save, restore
+	s = New(10, 2)
+	fmt.Fprint(s, "ab\0337cd\0338X\033[sY\033[2;1HZ\033[uW")
+	assert.Equal(t, "abXW\nZ", s.String())
+}
+
+func TestScreen_Write_erase(t *testing.T) {
+	var fill = func() *Screen {
+		var s = New(3, 3)
+		fmt.Fprint(s, "abc\ndef\nghi\033[2;2H")
+		return s
+	}
+	for seq, want := range map[string]string{
+		"\033[K":               "abc\nd\nghi",
+		"\033[0K":              "abc\nd\nghi",
+		"\033[1K":              "abc\n  f\nghi",
+		"\033[2K":              "abc\n\nghi",
+		"\033[J":               "abc\nd",
+		"\033[1J":              "\n  f\nghi",
+		"\033[2J":              "",
+		"\033c":                "",
+		"\033[?2026h\033[?25l": "abc\ndef\nghi",
+	} {
+		var s = fill()
+		fmt.Fprint(s, seq)
+		assert.Equal(t, want, s.String(), "%q", seq)
+	}
+}
+
+func TestScreen_table(t *testing.T) {
+	var tb = table.New("NAME", "STATUS")
+	tb.Colorizer = au
+	tb.Append("web", au.Green("ok"))
+	var s = New(40, 10)
+	tb.WriteTo(s)
+	assert.Equal(t, ""+
+		"┌──────┬────────┐\n"+
+		"│ NAME │ STATUS │\n"+
+		"├──────┼────────┤\n"+
+		"│ web  │ ok     │\n"+
+		"└──────┴────────┘", s.String())
+	assert.Equal(t, aurora.BoldFm, s.Cell(1, 2).Color)
+	assert.Equal(t, aurora.GreenFg, s.Cell(3, 9).Color)
+}
+
+func TestScreen_live(t *testing.T) {
+	var s = New(20, 5)
+	var a = live.New(s, 0)
+	a.Colorizer = au
+	a.Interactive = true
+	require.NoError(t, a.Update("a", "b", "c"))
+	require.NoError(t, a.Update("a", au.Red("B"), "c"))
+	fmt.Fprintln(a, "log line")
+	require.NoError(t, a.Update("a", "B"))
+	require.NoError(t, a.Close())
+	assert.Equal(t, "log line\na\nB", s.String())
+	assert.Equal(t, aurora.Color(0), s.Cell(2, 0).Color)
+}
+
+func Example() {
+	var scr = New(80, 24)
+	fmt.Fprint(scr, au.Bold(au.Red("error")), ": ",
+		au.Hyperlink("docs", "https://example.com"))
+	fmt.Println(scr.String())
+	fmt.Println(scr.Cell(0, 0).Color)
+	fmt.Println(scr.Cell(0, 7).Link)
+
+	// Output:
+	// error: docs
+	// BoldFm|RedFg
+	// https://example.com
+}
@@ -0,0 +1,139 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// DefaultWatchInterval is default polling interval of a ConfigWatcher.
+const DefaultWatchInterval = time.Second
+
+// A ConfigWatcher polls a configuration file and reconfigures a colorizer
+// when the file changes. It doesn't require any file system notifications
+// and works everywhere. For example
+//
+//	var w = aurora.ConfigWatcher{Path: "/etc/app/colors.json"}
+//	go w.Watch(ctx, au)
+type ConfigWatcher struct {
+	// Path to the configuration file.
+	Path string
+	// Interval of polling. The DefaultWatchInterval used if it's zero.
+	Interval time.Duration
+	// Decode given content of the file into given Config. The Config
+	// contains configurations of the colorizer at the moment the Watch
+	// called. If it's nil, then JSON is used.
+	Decode func(data []byte, conf *Config) error
+	// OnError is called, if it's not nil, for an error of reading or
+	// decoding the file. The same error is reported once, until the file
+	// is loaded or the error changes. Previous configurations are kept on
+	// error.
+	OnError func(err error)
+}
+
+func (w *ConfigWatcher) interval() time.Duration {
+	if w.Interval <= 0 {
+		return DefaultWatchInterval
+	}
+	return w.Interval
+}
+
+func (w *ConfigWatcher) decode(data []byte, conf *Config) error {
+	if w.Decode == nil {
+		return json.Unmarshal(data, conf)
+	}
+	return w.Decode(data, conf)
+}
+
+// onError reports given error if it differs from the last reported one,
+// the nil error resets the last one
+func (w *ConfigWatcher) onError(err error, last *string) {
+	if err == nil {
+		*last = ""
+		return
+	}
+	if err.Error() == *last {
+		return
+	}
+	*last = err.Error()
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
+
+// Watch the file, reconfiguring given colorizer on changes. The file is
+// loaded at start. It blocks until given context is done and returns
+// error of the context.
+func (w *ConfigWatcher) Watch(ctx context.Context, a *Aurora) error {
+	var (
+		base    = a.Config()
+		ticker  = time.NewTicker(w.interval())
+		modTime time.Time
+		size    int64  = -1
+		lastErr string // last reported error
+	)
+	defer ticker.Stop()
+	for {
+		if fi, err := os.Stat(w.Path); err != nil {
+			modTime, size = time.Time{}, -1 // reload when it's back
+			w.onError(err, &lastErr)
+		} else if !fi.ModTime().Equal(modTime) || fi.Size() != size {
+			modTime, size = fi.ModTime(), fi.Size()
+			w.onError(w.reload(a, base), &lastErr)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *ConfigWatcher) reload(a *Aurora, base Config) (err error) {
+	var data []byte
+	if data, err = os.ReadFile(w.Path); err != nil {
+		return
+	}
+	var conf = base
+	if err = w.decode(data, &conf); err != nil {
+		return
+	}
+	a.SetConfig(conf)
+	return
+}
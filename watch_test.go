@@ -0,0 +1,178 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigWatcher_Watch(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "colors.json")
+		a    = New()
+
+		mu   sync.Mutex
+		errs []error
+	)
+	require.NoError(t, os.WriteFile(path, []byte(`{"colors":false}`), 0600))
+
+	var w = ConfigWatcher{
+		Path:     path,
+		Interval: time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+	}
+	var ctx, cancel = context.WithCancel(context.Background())
+	var done = make(chan error)
+	go func() { done <- w.Watch(ctx, a) }()
+
+	// loaded at start
+	assert.Eventually(t, func() bool {
+		return !a.Config().Colors
+	}, time.Second, time.Millisecond)
+	assert.True(t, a.Config().Hyperlinks, "keeps base configurations")
+
+	// invalid file
+	require.NoError(t, os.WriteFile(path, []byte(`{"colors":`), 0600))
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) > 0
+	}, time.Second, time.Millisecond)
+	assert.False(t, a.Config().Colors, "keeps previous configurations")
+
+	// changed
+	require.NoError(t, os.WriteFile(path,
+		[]byte(`{"colors":true,"theme":{"error":"magenta"}}`), 0600))
+	assert.Eventually(t, func() bool {
+		return a.Config().Colors
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, "\033[35mx\033[0m", a.Role(RoleError, "x").String())
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestConfigWatcher_Decode(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "colors")
+		a    = New()
+		bad  = errors.New("bad")
+	)
+	require.NoError(t, os.WriteFile(path, []byte(`off`), 0600))
+	var w = ConfigWatcher{
+		Path: path,
+		Decode: func(data []byte, conf *Config) error {
+			if string(data) != "off" {
+				return bad
+			}
+			conf.Colors = false
+			return nil
+		},
+	}
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, w.Watch(ctx, a), context.Canceled)
+	assert.False(t, a.Config().Colors)
+	// default decoder
+	w.Decode = nil
+	assert.Error(t, w.decode([]byte(`off`), new(Config)))
+	var conf Config
+	assert.NoError(t, w.decode([]byte(`{"colors":true}`), &conf))
+	assert.True(t, conf.Colors)
+}
+
+func TestConfigWatcher_errorOnce(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "colors.json")
+		a    = New()
+
+		mu   sync.Mutex
+		errs []error
+	)
+	var count = func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs)
+	}
+	var w = ConfigWatcher{
+		Path:     path,
+		Interval: time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+	}
+	var ctx, cancel = context.WithCancel(context.Background())
+	var done = make(chan error)
+	go func() { done <- w.Watch(ctx, a) }()
+
+	// missing file reported once
+	assert.Eventually(t, func() bool { return count() == 1 }, time.Second,
+		time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 1, count())
+	mu.Lock()
+	assert.ErrorIs(t, errs[0], os.ErrNotExist)
+	mu.Unlock()
+
+	// loaded, then removed again
+	require.NoError(t, os.WriteFile(path, []byte(`{"colors":false}`), 0600))
+	assert.Eventually(t, func() bool {
+		return !a.Config().Colors
+	}, time.Second, time.Millisecond)
+	require.NoError(t, os.Remove(path))
+	assert.Eventually(t, func() bool { return count() == 2 }, time.Second,
+		time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 2, count())
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
@@ -0,0 +1,298 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// wide (two columns) ranges of East Asian Wide and Fullwidth
+// characters and emoji presentation characters
+var wideRanges = [...][2]rune{
+	{0x1100, 0x115f},
+	{0x231a, 0x231b},
+	{0x2329, 0x232a},
+	{0x23e9, 0x23ec},
+	{0x23f0, 0x23f0},
+	{0x23f3, 0x23f3},
+	{0x25fd, 0x25fe},
+	{0x2614, 0x2615},
+	{0x2648, 0x2653},
+	{0x267f, 0x267f},
+	{0x2693, 0x2693},
+	{0x26a1, 0x26a1},
+	{0x26aa, 0x26ab},
+	{0x26bd, 0x26be},
+	{0x26c4, 0x26c5},
+	{0x26ce, 0x26ce},
+	{0x26d4, 0x26d4},
+	{0x26ea, 0x26ea},
+	{0x26f2, 0x26f3},
+	{0x26f5, 0x26f5},
+	{0x26fa, 0x26fa},
+	{0x26fd, 0x26fd},
+	{0x2705, 0x2705},
+	{0x270a, 0x270b},
+	{0x2728, 0x2728},
+	{0x274c, 0x274c},
+	{0x274e, 0x274e},
+	{0x2753, 0x2755},
+	{0x2757, 0x2757},
+	{0x2795, 0x2797},
+	{0x27b0, 0x27b0},
+	{0x27bf, 0x27bf},
+	{0x2b1b, 0x2b1c},
+	{0x2b50, 0x2b50},
+	{0x2b55, 0x2b55},
+	{0x2e80, 0x303e},
+	{0x3041, 0x33ff},
+	{0x3400, 0x4dbf},
+	{0x4e00, 0x9fff},
+	{0xa000, 0xa4cf},
+	{0xa960, 0xa97f},
+	{0xac00, 0xd7a3},
+	{0xf900, 0xfaff},
+	{0xfe10, 0xfe19},
+	{0xfe30, 0xfe6f},
+	{0xff00, 0xff60},
+	{0xffe0, 0xffe6},
+	{0x16fe0, 0x16fe4},
+	{0x17000, 0x18cff},
+	{0x1b000, 0x1b2ff},
+	{0x1f004, 0x1f004},
+	{0x1f0cf, 0x1f0cf},
+	{0x1f18e, 0x1f18e},
+	{0x1f191, 0x1f19a},
+	{0x1f200, 0x1f251},
+	{0x1f300, 0x1f320},
+	{0x1f32d, 0x1f335},
+	{0x1f337, 0x1f37c},
+	{0x1f37e, 0x1f393},
+	{0x1f3a0, 0x1f3ca},
+	{0x1f3cf, 0x1f3d3},
+	{0x1f3e0, 0x1f3f0},
+	{0x1f3f4, 0x1f3f4},
+	{0x1f3f8, 0x1f43e},
+	{0x1f440, 0x1f440},
+	{0x1f442, 0x1f4fc},
+	{0x1f4ff, 0x1f53d},
+	{0x1f54b, 0x1f54e},
+	{0x1f550, 0x1f567},
+	{0x1f57a, 0x1f57a},
+	{0x1f595, 0x1f596},
+	{0x1f5a4, 0x1f5a4},
+	{0x1f5fb, 0x1f64f},
+	{0x1f680, 0x1f6c5},
+	{0x1f6cc, 0x1f6cc},
+	{0x1f6d0, 0x1f6d2},
+	{0x1f6d5, 0x1f6d7},
+	{0x1f6eb, 0x1f6ec},
+	{0x1f6f4, 0x1f6fc},
+	{0x1f7e0, 0x1f7eb},
+	{0x1f90c, 0x1f93a},
+	{0x1f93c, 0x1f945},
+	{0x1f947, 0x1f9ff},
+	{0x1fa70, 0x1faff},
+	{0x20000, 0x2fffd},
+	{0x30000, 0x3fffd},
+}
+
+func isWide(r rune) bool {
+	var lo, hi = 0, len(wideRanges)
+	for lo < hi {
+		var m = (lo + hi) / 2
+		switch {
+		case r < wideRanges[m][0]:
+			hi = m
+		case r > wideRanges[m][1]:
+			lo = m + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// RuneWidth returns number of terminal columns the rune takes. It's 0 for
+// control characters, combining marks and other zero-width characters, 2
+// for East Asian wide and fullwidth characters and emoji, and 1 for others.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20, r == 0x7f:
+		return 0 // control
+	case 0x80 <= r && r < 0xa0:
+		return 0 // C1 control
+	case r < 0x300:
+		return 1 // fast path for Latin
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0 // combining marks, zero-width joiners, etc
+	case 0x1160 <= r && r <= 0x11ff:
+		return 0 // Hangul Jamo medial vowels and final consonants
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// escapeLen returns length of an escape sequence at the beginning of given
+// string, or zero if the string doesn't start with an escape sequence.
+// It recognizes CSI sequences (including SGR), OSC sequences (including
+// hyperlinks) terminated by BEL or ST, other string sequences (DCS, SOS, PM,
+// APC) and two-byte sequences. An unterminated sequence takes the rest of
+// the string.
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != '\033' {
+		if len(s) == 1 && s[0] == '\033' {
+			return 1
+		}
+		return 0
+	}
+	switch s[1] {
+	case '[': // CSI
+		for i := 2; i < len(s); i++ {
+			if 0x40 <= s[i] && s[i] <= 0x7e {
+				return i + 1 // final byte
+			}
+		}
+		return len(s)
+	case ']', 'P', 'X', '^', '_': // OSC, DCS, SOS, PM, APC
+		for i := 2; i < len(s); i++ {
+			switch {
+			case s[i] == '\a':
+				return i + 1 // BEL
+			case s[i] == '\033' && i+1 < len(s) && s[i+1] == '\\':
+				return i + 2 // ST
+			}
+		}
+		return len(s)
+	}
+	// intermediate bytes and a final byte
+	for i := 1; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x2f {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// DisplayWidth returns number of terminal columns given string takes. It
+// skips ANSI escape sequences, including colors, formats and hyperlinks,
+// and takes into account wide and zero-width characters. See RuneWidth.
+func DisplayWidth(s string) (width int) {
+	for i := 0; i < len(s); {
+		if s[i] == '\033' {
+			i += escapeLen(s[i:])
+			continue
+		}
+		if s[i] < utf8.RuneSelf {
+			if s[i] >= 0x20 && s[i] != 0x7f {
+				width++
+			}
+			i++
+			continue
+		}
+		var r, size = utf8.DecodeRuneInString(s[i:])
+		width += RuneWidth(r)
+		i += size
+	}
+	return
+}
+
+// Strip returns given string without ANSI escape sequences.
+func Strip(s string) string {
+	var i = 0
+	for i < len(s) && s[i] != '\033' {
+		i++
+	}
+	if i == len(s) {
+		return s // nothing to strip
+	}
+	var t = make([]byte, 0, len(s))
+	for i = 0; i < len(s); {
+		if s[i] == '\033' {
+			i += escapeLen(s[i:])
+			continue
+		}
+		var j = i + 1
+		for j < len(s) && s[j] != '\033' {
+			j++
+		}
+		t = append(t, s[i:j]...)
+		i = j
+	}
+	return string(t)
+}
+
+// Truncate given string to given display width. If the string is
+// truncated, then given tail, like "…", is appended to the string, and the
+// tail fits the width. The tail is dropped if it's wider than the width.
+// Escape sequences are kept, thus colors of the string are reset and
+// hyperlinks are closed properly.
+func Truncate(s string, width int, tail string) string {
+	if DisplayWidth(s) <= width {
+		return s
+	}
+	var tw = DisplayWidth(tail)
+	if tw > width {
+		tail, tw = "", 0
+	}
+	var (
+		limit = width - tw
+		t     = make([]byte, 0, len(s)+len(tail))
+		w     int
+		cut   bool
+	)
+	for i := 0; i < len(s); {
+		if s[i] == '\033' {
+			var n = escapeLen(s[i:])
+			t = append(t, s[i:i+n]...)
+			i += n
+			continue
+		}
+		var r, size = utf8.DecodeRuneInString(s[i:])
+		if !cut {
+			if rw := RuneWidth(r); w+rw <= limit {
+				t = append(t, s[i:i+size]...)
+				w += rw
+			} else {
+				t, cut = append(t, tail...), true
+			}
+		}
+		i += size
+	}
+	return string(t)
+}
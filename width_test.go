@@ -0,0 +1,132 @@
+//
+// Copyright (c) 2016-2022 The Aurora Authors. All rights reserved.
+// This program is free software. It comes without any warranty,
+// to the extent permitted by applicable law. You can redistribute
+// it and/or modify it under the terms of the Unlicense. See LICENSE
+// file for more details or see below.
+//
+
+//
+// This is free and unencumbered software released into the public domain.
+//
+// Anyone is free to copy, modify, publish, use, compile, sell, or
+// distribute this software, either in source code form or as a compiled
+// binary, for any purpose, commercial or non-commercial, and by any
+// means.
+//
+// In jurisdictions that recognize copyright laws, the author or authors
+// of this software dedicate any and all copyright interest in the
+// software to the public domain. We make this dedication for the benefit
+// of the public at large and to the detriment of our heirs and
+// successors. We intend this dedication to be an overt act of
+// relinquishment in perpetuity of all present and future rights to this
+// software under copyright law.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+// OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+// OTHER DEALINGS IN THE SOFTWARE.
+//
+// For more information, please refer to <http://unlicense.org/>
+//
+
+package aurora
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuneWidth(t *testing.T) {
+	for r, want := range map[rune]int{
+		'a':      1,
+		'\t':     0,
+		'\x7f':   0,
+		'\u0085': 0, // C1
+		'é':      1,
+		'\u0301': 0, // combining acute accent
+		'\u200d': 0, // zero width joiner
+		'世':      2,
+		'ｱ':      1, // halfwidth katakana
+		'Ａ':      2, // fullwidth A
+		'😀':      2,
+		'→':      1,
+	} {
+		assert.Equal(t, want, RuneWidth(r), "%q", r)
+	}
+}
+
+func Test_escapeLen(t *testing.T) {
+	for s, want := range map[string]int{
+		"":                         0,
+		"x":                        0,
+		"\033":                     1,
+		"\033[0m x":                4,
+		"\033[1;38;5;100m":         13,
+		"\033[31":                  4, // unterminated
+		"\033]8;;http://x\033\\ a": 15,
+		"\033]8;;http://x\a a":     14,
+		"\033]8;;":                 5, // unterminated
+		"\033(B x":                 3,
+		"\0337 x":                  2,
+		"\033P1$r0m\033\\ x":       9,
+	} {
+		assert.Equal(t, want, escapeLen(s), "%q", s)
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	for s, want := range map[string]int{
+		"":                       0,
+		"hello":                  5,
+		Red("hello").String():    5,
+		Bold(Red("世界")).String(): 4,
+		Hyperlink(Red("link"), "http://example.com").String(): 4,
+		"é":          1,
+		"\033[31":     0,
+		"a\tb":        2,
+		"\033]8;;x\a": 0,
+	} {
+		assert.Equal(t, want, DisplayWidth(s), "%q", s)
+	}
+}
+
+func TestStrip(t *testing.T) {
+	for s, want := range map[string]string{
+		"":                                "",
+		"hello":                           "hello",
+		Red("hello").String():             "hello",
+		Sprintf(Red("a %s c"), Blue("b")): "a b c",
+		Hyperlink(Red("link"), "http://example.com").String(): "link",
+	} {
+		assert.Equal(t, want, Strip(s), "%q", s)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	for _, tt := range []struct {
+		s     string
+		width int
+		tail  string
+		want  string
+	}{
+		{"", 0, "…", ""},
+		{"hello", 5, "…", "hello"},
+		{"hello", 4, "…", "hel…"},
+		{"hello", 4, "", "hell"},
+		{"hello", 2, "...", "he"},
+		{"hello", 0, "…", ""},
+		{"世界世界", 5, "…", "世界…"},
+		{"世界世界", 4, "", "世界"},
+		{"\033[31mhello\033[0m", 3, "…", "\033[31mhe…\033[0m"},
+		{"\033]8;;http://x\033\\link\033]8;;\033\\", 2, "",
+			"\033]8;;http://x\033\\li\033]8;;\033\\"},
+	} {
+		assert.Equal(t, tt.want, Truncate(tt.s, tt.width, tt.tail),
+			"%q %d %q", tt.s, tt.width, tt.tail)
+	}
+}
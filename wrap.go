@@ -35,9 +35,69 @@
 
 package aurora
 
-// DefaultColorizer is global colorizer that used for package root color
-// methods.
-var DefaultColorizer = New(WithColors(true), WithHyperlinks(true))
+import (
+	"io"
+	"os"
+	"sync/atomic"
+)
+
+// DefaultColorizer is global colorizer used for package root color
+// methods. It's the initial Default colorizer.
+//
+// Deprecated: Use Default and SetDefault instead. Assigning to the
+// DefaultColorizer still replaces the Default colorizer, but it's not safe
+// for concurrent use with package root methods. The latest of assignment
+// and SetDefault call wins. Code like
+//
+//	aurora.DefaultColorizer = aurora.New(aurora.WithColors(false))
+//
+// should be replaced with
+//
+//	aurora.SetDefault(aurora.New(aurora.WithColors(false)))
+var DefaultColorizer = newDefault()
+
+// defaults is colorizer set by the SetDefault and value of the
+// DefaultColorizer at the moment, to detect assignments to it
+type defaults struct {
+	au       *Aurora
+	assigned *Aurora
+}
+
+var defaultColorizer atomic.Pointer[defaults]
+
+func init() {
+	defaultColorizer.Store(&defaults{
+		au:       DefaultColorizer,
+		assigned: DefaultColorizer,
+	})
+}
+
+// new Aurora configured for the standard output
+func newDefault() *Aurora {
+	var conf = DetectConfig(os.Stdout)
+	return New(conf.Options()...)
+}
+
+// Default returns global colorizer that used for package root color
+// methods. Initially, it's configured for the standard output by the
+// DetectConfig.
+func Default() *Aurora {
+	var d = defaultColorizer.Load()
+	if a := DefaultColorizer; a != d.assigned && a != nil {
+		return a // assigned after last SetDefault
+	}
+	return d.au
+}
+
+// SetDefault replaces global colorizer that used for package root color
+// methods. It's safe to call it concurrently with package root methods.
+// The nil means a new colorizer configured by the DetectConfig.
+func SetDefault(a *Aurora) {
+	if a == nil {
+		a = newDefault()
+	}
+	defaultColorizer.Store(&defaults{au: a, assigned: DefaultColorizer})
+}
 
 // Colorize wraps given value into Value with given colors. For example
 //
@@ -51,18 +111,18 @@ var DefaultColorizer = New(WithColors(true), WithHyperlinks(true))
 //
 // clears red color from value.
 func Colorize(arg interface{}, color Color) Value {
-	return DefaultColorizer.Colorize(arg, color)
+	return Default().Colorize(arg, color)
 }
 
 // Reset wraps given argument returning Value without formats, colors and links.
 func Reset(arg interface{}) Value {
-	return DefaultColorizer.Reset(arg)
+	return Default().Reset(arg)
 }
 
 // Clear wraps given argument returning Value without formats and colors. But
 // preserving links.
 func Clear(arg interface{}) Value {
-	return DefaultColorizer.Clear(arg)
+	return Default().Clear(arg)
 }
 
 //
@@ -71,93 +131,156 @@ func Clear(arg interface{}) Value {
 
 // Bold or increased intensity (1).
 func Bold(arg interface{}) Value {
-	return DefaultColorizer.Bold(arg)
+	return Default().Bold(arg)
 }
 
 // Faint decreases intensity (2). The Faint rejects the Bold.
 func Faint(arg interface{}) Value {
-	return DefaultColorizer.Faint(arg)
+	return Default().Faint(arg)
 }
 
 // DoublyUnderline or Bold off, double-underline per ECMA-48 (21).
 func DoublyUnderline(arg interface{}) Value {
-	return DefaultColorizer.DoublyUnderline(arg)
+	return Default().DoublyUnderline(arg)
 }
 
 // Fraktur is rarely supported (20).
 func Fraktur(arg interface{}) Value {
-	return DefaultColorizer.Fraktur(arg)
+	return Default().Fraktur(arg)
 }
 
 // Italic is not widely supported, sometimes treated as inverse (3).
 func Italic(arg interface{}) Value {
-	return DefaultColorizer.Italic(arg)
+	return Default().Italic(arg)
 }
 
 // Underline (4).
 func Underline(arg interface{}) Value {
-	return DefaultColorizer.Underline(arg)
+	return Default().Underline(arg)
 }
 
 // SlowBlink makes text blink less than 150 per minute (5).
 func SlowBlink(arg interface{}) Value {
-	return DefaultColorizer.SlowBlink(arg)
+	return Default().SlowBlink(arg)
 }
 
 // RapidBlink makes text blink 150+ per minute. It is not widely supported (6).
 func RapidBlink(arg interface{}) Value {
-	return DefaultColorizer.RapidBlink(arg)
+	return Default().RapidBlink(arg)
 }
 
 // Blink is alias for the SlowBlink.
 func Blink(arg interface{}) Value {
-	return DefaultColorizer.Blink(arg)
+	return Default().Blink(arg)
 }
 
 // Reverse video, swap foreground and background colors (7).
 func Reverse(arg interface{}) Value {
-	return DefaultColorizer.Reverse(arg)
+	return Default().Reverse(arg)
 }
 
 // Inverse is alias for the Reverse
 func Inverse(arg interface{}) Value {
-	return DefaultColorizer.Inverse(arg)
+	return Default().Inverse(arg)
 }
 
 // Conceal hides text, preserving an ability to select the text and copy it. It
 // is not widely supported (8).
 func Conceal(arg interface{}) Value {
-	return DefaultColorizer.Conceal(arg)
+	return Default().Conceal(arg)
 }
 
 // Hidden is alias for the Conceal
 func Hidden(arg interface{}) Value {
-	return DefaultColorizer.Hidden(arg)
+	return Default().Hidden(arg)
 }
 
 // CrossedOut makes characters legible, but marked for deletion (9).
 func CrossedOut(arg interface{}) Value {
-	return DefaultColorizer.CrossedOut(arg)
+	return Default().CrossedOut(arg)
 }
 
 // StrikeThrough is alias for the CrossedOut.
 func StrikeThrough(arg interface{}) Value {
-	return DefaultColorizer.StrikeThrough(arg)
+	return Default().StrikeThrough(arg)
 }
 
 // Framed (51).
 func Framed(arg interface{}) Value {
-	return DefaultColorizer.Framed(arg)
+	return Default().Framed(arg)
 }
 
 // Encircled (52).
 func Encircled(arg interface{}) Value {
-	return DefaultColorizer.Encircled(arg)
+	return Default().Encircled(arg)
 }
 
 // Overlined (53).
 func Overlined(arg interface{}) Value {
-	return DefaultColorizer.Overlined(arg)
+	return Default().Overlined(arg)
+}
+
+// Curly underline, not widely supported (4:3).
+func CurlyUnderline(arg interface{}) Value {
+	return Default().CurlyUnderline(arg)
+}
+
+// Dotted underline, not widely supported (4:4).
+func DottedUnderline(arg interface{}) Value {
+	return Default().DottedUnderline(arg)
+}
+
+// Dashed underline, not widely supported (4:5).
+func DashedUnderline(arg interface{}) Value {
+	return Default().DashedUnderline(arg)
+}
+
+// Proportional spacing, rarely supported (26).
+func ProportionalSpacing(arg interface{}) Value {
+	return Default().ProportionalSpacing(arg)
+}
+
+// IdeogramUnderline or right side line, rarely supported (60).
+func IdeogramUnderline(arg interface{}) Value {
+	return Default().IdeogramUnderline(arg)
+}
+
+// IdeogramDoubleUnderline or double line on the right side, rarely
+// supported (61).
+func IdeogramDoubleUnderline(arg interface{}) Value {
+	return Default().IdeogramDoubleUnderline(arg)
+}
+
+// IdeogramOverline or left side line, rarely supported (62).
+func IdeogramOverline(arg interface{}) Value {
+	return Default().IdeogramOverline(arg)
+}
+
+// IdeogramDoubleOverline or double line on the left side, rarely
+// supported (63).
+func IdeogramDoubleOverline(arg interface{}) Value {
+	return Default().IdeogramDoubleOverline(arg)
+}
+
+// IdeogramStress marking, rarely supported (64).
+func IdeogramStress(arg interface{}) Value {
+	return Default().IdeogramStress(arg)
+}
+
+// Superscript, reset the Subscript, rarely supported (73).
+func Superscript(arg interface{}) Value {
+	return Default().Superscript(arg)
+}
+
+// Subscript, reset the Superscript, rarely supported (74).
+func Subscript(arg interface{}) Value {
+	return Default().Subscript(arg)
+}
+
+// Font sets alternative font from 1 to 9 (11-19), rarely supported. The
+// zero is the primary font (10).
+func Font(n FontIndex, arg interface{}) Value {
+	return Default().Font(n, arg)
 }
 
 //
@@ -167,42 +290,42 @@ func Overlined(arg interface{}) Value {
 
 // Black foreground color (30)
 func Black(arg interface{}) Value {
-	return DefaultColorizer.Black(arg)
+	return Default().Black(arg)
 }
 
 // Red foreground color (31)
 func Red(arg interface{}) Value {
-	return DefaultColorizer.Red(arg)
+	return Default().Red(arg)
 }
 
 // Green foreground color (32)
 func Green(arg interface{}) Value {
-	return DefaultColorizer.Green(arg)
+	return Default().Green(arg)
 }
 
 // Yellow foreground color (33)
 func Yellow(arg interface{}) Value {
-	return DefaultColorizer.Yellow(arg)
+	return Default().Yellow(arg)
 }
 
 // Blue foreground color (34)
 func Blue(arg interface{}) Value {
-	return DefaultColorizer.Blue(arg)
+	return Default().Blue(arg)
 }
 
 // Magenta foreground color (35)
 func Magenta(arg interface{}) Value {
-	return DefaultColorizer.Magenta(arg)
+	return Default().Magenta(arg)
 }
 
 // Cyan foreground color (36)
 func Cyan(arg interface{}) Value {
-	return DefaultColorizer.Cyan(arg)
+	return Default().Cyan(arg)
 }
 
 // White foreground color (37)
 func White(arg interface{}) Value {
-	return DefaultColorizer.White(arg)
+	return Default().White(arg)
 }
 
 //
@@ -211,42 +334,42 @@ func White(arg interface{}) Value {
 
 // BrightBlack foreground color (90)
 func BrightBlack(arg interface{}) Value {
-	return DefaultColorizer.BrightBlack(arg)
+	return Default().BrightBlack(arg)
 }
 
 // BrightRed foreground color (91)
 func BrightRed(arg interface{}) Value {
-	return DefaultColorizer.BrightRed(arg)
+	return Default().BrightRed(arg)
 }
 
 // BrightGreen foreground color (92)
 func BrightGreen(arg interface{}) Value {
-	return DefaultColorizer.BrightGreen(arg)
+	return Default().BrightGreen(arg)
 }
 
 // BrightYellow foreground color (93)
 func BrightYellow(arg interface{}) Value {
-	return DefaultColorizer.BrightYellow(arg)
+	return Default().BrightYellow(arg)
 }
 
 // BrightBlue foreground color (94)
 func BrightBlue(arg interface{}) Value {
-	return DefaultColorizer.BrightBlue(arg)
+	return Default().BrightBlue(arg)
 }
 
 // BrightMagenta foreground color (95)
 func BrightMagenta(arg interface{}) Value {
-	return DefaultColorizer.BrightMagenta(arg)
+	return Default().BrightMagenta(arg)
 }
 
 // BrightCyan foreground color (96)
 func BrightCyan(arg interface{}) Value {
-	return DefaultColorizer.BrightCyan(arg)
+	return Default().BrightCyan(arg)
 }
 
 // BrightWhite foreground color (97)
 func BrightWhite(arg interface{}) Value {
-	return DefaultColorizer.BrightWhite(arg)
+	return Default().BrightWhite(arg)
 }
 
 //
@@ -260,12 +383,18 @@ func BrightWhite(arg interface{}) Value {
 //	 16-231:  6 × 6 × 6 cube (216 colors): 16 + 36 × r + 6 × g + b (0 ≤ r, g, b ≤ 5)
 //	232-255:  grayscale from black to white in 24 steps
 func Index(n ColorIndex, arg interface{}) Value {
-	return DefaultColorizer.Index(n, arg)
+	return Default().Index(n, arg)
+}
+
+// TrueColor sets 24-bit foreground color (38;2;r;g;b), used with the
+// ProfileTrueColor. Other profiles use nearest 8-bit color, see RGB.Index.
+func TrueColor(rgb RGB, arg interface{}) Value {
+	return Default().TrueColor(rgb, arg)
 }
 
 // Gray from 0 to 24.
 func Gray(n GrayIndex, arg interface{}) Value {
-	return DefaultColorizer.Gray(n, arg)
+	return Default().Gray(n, arg)
 }
 
 //
@@ -275,42 +404,42 @@ func Gray(n GrayIndex, arg interface{}) Value {
 
 // BgBlack background color (40)
 func BgBlack(arg interface{}) Value {
-	return DefaultColorizer.BgBlack(arg)
+	return Default().BgBlack(arg)
 }
 
 // BgRed background color (41)
 func BgRed(arg interface{}) Value {
-	return DefaultColorizer.BgRed(arg)
+	return Default().BgRed(arg)
 }
 
 // BgGreen background color (42)
 func BgGreen(arg interface{}) Value {
-	return DefaultColorizer.BgGreen(arg)
+	return Default().BgGreen(arg)
 }
 
 // BgYellow background color (43)
 func BgYellow(arg interface{}) Value {
-	return DefaultColorizer.BgYellow(arg)
+	return Default().BgYellow(arg)
 }
 
 // BgBlue background color (44)
 func BgBlue(arg interface{}) Value {
-	return DefaultColorizer.BgBlue(arg)
+	return Default().BgBlue(arg)
 }
 
 // BgMagenta background color (45)
 func BgMagenta(arg interface{}) Value {
-	return DefaultColorizer.BgMagenta(arg)
+	return Default().BgMagenta(arg)
 }
 
 // BgCyan background color (46)
 func BgCyan(arg interface{}) Value {
-	return DefaultColorizer.BgCyan(arg)
+	return Default().BgCyan(arg)
 }
 
 // BgWhite background color (47)
 func BgWhite(arg interface{}) Value {
-	return DefaultColorizer.BgWhite(arg)
+	return Default().BgWhite(arg)
 }
 
 //
@@ -319,42 +448,42 @@ func BgWhite(arg interface{}) Value {
 
 // BgBrightBlack background color (100)
 func BgBrightBlack(arg interface{}) Value {
-	return DefaultColorizer.BgBrightBlack(arg)
+	return Default().BgBrightBlack(arg)
 }
 
 // BgBrightRed background color (101)
 func BgBrightRed(arg interface{}) Value {
-	return DefaultColorizer.BgBrightRed(arg)
+	return Default().BgBrightRed(arg)
 }
 
 // BgBrightGreen background color (102)
 func BgBrightGreen(arg interface{}) Value {
-	return DefaultColorizer.BgBrightGreen(arg)
+	return Default().BgBrightGreen(arg)
 }
 
 // BgBrightYellow background color (103)
 func BgBrightYellow(arg interface{}) Value {
-	return DefaultColorizer.BgBrightYellow(arg)
+	return Default().BgBrightYellow(arg)
 }
 
 // BgBrightBlue background color (104)
 func BgBrightBlue(arg interface{}) Value {
-	return DefaultColorizer.BgBrightBlue(arg)
+	return Default().BgBrightBlue(arg)
 }
 
 // BgBrightMagenta background color (105)
 func BgBrightMagenta(arg interface{}) Value {
-	return DefaultColorizer.BgBrightMagenta(arg)
+	return Default().BgBrightMagenta(arg)
 }
 
 // BgBrightCyan background color (106)
 func BgBrightCyan(arg interface{}) Value {
-	return DefaultColorizer.BgBrightCyan(arg)
+	return Default().BgBrightCyan(arg)
 }
 
 // BgBrightWhite background color (107)
 func BgBrightWhite(arg interface{}) Value {
-	return DefaultColorizer.BgBrightWhite(arg)
+	return Default().BgBrightWhite(arg)
 }
 
 //
@@ -368,12 +497,147 @@ func BgBrightWhite(arg interface{}) Value {
 //	 16-231:  6 × 6 × 6 cube (216 colors): 16 + 36 × r + 6 × g + b (0 ≤ r, g, b ≤ 5)
 //	232-255:  grayscale from black to white in 24 steps
 func BgIndex(n ColorIndex, arg interface{}) Value {
-	return DefaultColorizer.BgIndex(n, arg)
+	return Default().BgIndex(n, arg)
 }
 
 // BgGray from 0 to 24.
 func BgGray(n GrayIndex, arg interface{}) Value {
-	return DefaultColorizer.BgGray(n, arg)
+	return Default().BgGray(n, arg)
+}
+
+// BgTrueColor sets 24-bit background color (48;2;r;g;b). See TrueColor.
+func BgTrueColor(rgb RGB, arg interface{}) Value {
+	return Default().BgTrueColor(rgb, arg)
+}
+
+//
+// Underline color
+//
+
+// UnderlineIndex sets underline color, 8-bit pre-defined color from 0 to
+// 255 (58;5;n), not widely supported. See Index for details.
+func UnderlineIndex(n ColorIndex, arg interface{}) Value {
+	return Default().UnderlineIndex(n, arg)
+}
+
+// UnderlineGray sets gray underline color from 0 to 23.
+func UnderlineGray(n GrayIndex, arg interface{}) Value {
+	return Default().UnderlineGray(n, arg)
+}
+
+//
+// Removal
+//
+
+// NoBold removes Bold format.
+func NoBold(arg interface{}) Value {
+	return Default().NoBold(arg)
+}
+
+// NoFaint removes Faint format.
+func NoFaint(arg interface{}) Value {
+	return Default().NoFaint(arg)
+}
+
+// NoItalic removes Italic format.
+func NoItalic(arg interface{}) Value {
+	return Default().NoItalic(arg)
+}
+
+// NoUnderline removes Underline and DoublyUnderline formats.
+func NoUnderline(arg interface{}) Value {
+	return Default().NoUnderline(arg)
+}
+
+// NoBlink removes SlowBlink and RapidBlink formats.
+func NoBlink(arg interface{}) Value {
+	return Default().NoBlink(arg)
+}
+
+// NoReverse removes Reverse format.
+func NoReverse(arg interface{}) Value {
+	return Default().NoReverse(arg)
+}
+
+// NoConceal removes Conceal format.
+func NoConceal(arg interface{}) Value {
+	return Default().NoConceal(arg)
+}
+
+// NoCrossedOut removes CrossedOut format.
+func NoCrossedOut(arg interface{}) Value {
+	return Default().NoCrossedOut(arg)
+}
+
+// NoFraktur removes Fraktur format.
+func NoFraktur(arg interface{}) Value {
+	return Default().NoFraktur(arg)
+}
+
+// NoFramed removes Framed format.
+func NoFramed(arg interface{}) Value {
+	return Default().NoFramed(arg)
+}
+
+// NoEncircled removes Encircled format.
+func NoEncircled(arg interface{}) Value {
+	return Default().NoEncircled(arg)
+}
+
+// NoOverlined removes Overlined format.
+func NoOverlined(arg interface{}) Value {
+	return Default().NoOverlined(arg)
+}
+
+// NoForeground removes foreground color.
+func NoForeground(arg interface{}) Value {
+	return Default().NoForeground(arg)
+}
+
+// NoBackground removes background color.
+func NoBackground(arg interface{}) Value {
+	return Default().NoBackground(arg)
+}
+
+// NoUnderlineColor removes underline color.
+func NoUnderlineColor(arg interface{}) Value {
+	return Default().NoUnderlineColor(arg)
+}
+
+// NoProportionalSpacing removes ProportionalSpacing format.
+func NoProportionalSpacing(arg interface{}) Value {
+	return Default().NoProportionalSpacing(arg)
+}
+
+// NoIdeograms removes all ideogram formats.
+func NoIdeograms(arg interface{}) Value {
+	return Default().NoIdeograms(arg)
+}
+
+// NoScript removes Superscript and Subscript formats.
+func NoScript(arg interface{}) Value {
+	return Default().NoScript(arg)
+}
+
+// NoFont removes alternative font, using the primary one.
+func NoFont(arg interface{}) Value {
+	return Default().NoFont(arg)
+}
+
+// Without removes formats and colors of given Color from the argument.
+// See Color.Without.
+func Without(arg interface{}, color Color) Value {
+	return Default().Without(arg, color)
+}
+
+//
+// Semantic roles
+//
+
+// Role applies Style of given role of the Theme of the Default colorizer to
+// the argument. Unknown roles leave the argument as is.
+func Role(role string, arg interface{}) Value {
+	return Default().Role(role, arg)
 }
 
 //
@@ -400,17 +664,17 @@ func BgGray(n GrayIndex, arg interface{}) Value {
 //
 //	au.Hyperlink("Example", "http://example.com", aurora.HyperlinkID("10"))
 func Hyperlink(arg interface{}, target string, params ...HyperlinkParam) Value {
-	return DefaultColorizer.Hyperlink(arg, target, params...)
+	return Default().Hyperlink(arg, target, params...)
 }
 
 // HyperlinkTarget of the argument if it's a Value.
 func HyperlinkTarget(arg interface{}) (target string) {
-	return DefaultColorizer.HyperlinkTarget(arg)
+	return Default().HyperlinkTarget(arg)
 }
 
 // HyperlinkParams of the argument if it's a Value.
 func HyperlinkParams(arg interface{}) (params []HyperlinkParam) {
-	return DefaultColorizer.HyperlinkParams(arg)
+	return Default().HyperlinkParams(arg)
 }
 
 // Sprintf allows to use Value as format. For example
@@ -427,5 +691,53 @@ func HyperlinkParams(arg interface{}) (params []HyperlinkParam) {
 //
 // It applies own configurations to all given Values.
 func Sprintf(format interface{}, args ...interface{}) string {
-	return DefaultColorizer.Sprintf(format, args...)
+	return Default().Sprintf(format, args...)
+}
+
+// Sprint is like fmt.Sprint, but it applies configurations of the
+// Default colorizer to all given Values.
+func Sprint(args ...interface{}) string {
+	return Default().Sprint(args...)
+}
+
+// Sprintln is like fmt.Sprintln, but it applies configurations of the
+// Default colorizer to all given Values.
+func Sprintln(args ...interface{}) string {
+	return Default().Sprintln(args...)
+}
+
+// Fprintf is like the Sprintf, but it writes result to given io.Writer.
+func Fprintf(w io.Writer, format interface{}, args ...interface{}) (n int,
+	err error) {
+
+	return Default().Fprintf(w, format, args...)
+}
+
+// Fprint is like fmt.Fprint, but it applies configurations of the
+// Default colorizer to all given Values.
+func Fprint(w io.Writer, args ...interface{}) (n int, err error) {
+	return Default().Fprint(w, args...)
+}
+
+// Fprintln is like fmt.Fprintln, but it applies configurations of the
+// Default colorizer to all given Values.
+func Fprintln(w io.Writer, args ...interface{}) (n int, err error) {
+	return Default().Fprintln(w, args...)
+}
+
+// Printf is like the Fprintf, but it writes to standard output.
+func Printf(format interface{}, args ...interface{}) (n int, err error) {
+	return Default().Printf(format, args...)
+}
+
+// Print is like fmt.Print, but it applies configurations of the
+// Default colorizer to all given Values.
+func Print(args ...interface{}) (n int, err error) {
+	return Default().Print(args...)
+}
+
+// Println is like fmt.Println, but it applies configurations of the
+// Default colorizer to all given Values.
+func Println(args ...interface{}) (n int, err error) {
+	return Default().Println(args...)
 }
@@ -36,6 +36,7 @@
 package aurora
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -389,6 +390,47 @@ func Test_BgGray(t *testing.T) {
 		(Color(15+232)<<shiftBg)|flagBg|(Color(216)<<shiftFg)|flagFg)
 }
 
+func Test_TrueColor(t *testing.T) {
+	testFunc(t, "TrueColor", TrueColor(0xff8800, "x"),
+		Color(208)<<shiftFg|flagFg)
+	testFunc(t, "BgTrueColor", BgTrueColor(0xff8800, Bold("x")),
+		Color(208)<<shiftBg|flagBg|BoldFm)
+}
+
+func Test_underline(t *testing.T) {
+	testFunc(t, "CurlyUnderline", CurlyUnderline("x"), CurlyUnderlineFm)
+	testFunc(t, "DottedUnderline", DottedUnderline("x"), DottedUnderlineFm)
+	testFunc(t, "DashedUnderline", DashedUnderline(Underline("x")),
+		DashedUnderlineFm)
+	testFunc(t, "UnderlineIndex", UnderlineIndex(187, "x"),
+		Color(187)<<shiftUl|flagUl)
+	testFunc(t, "UnderlineGray", UnderlineGray(15, Bold("x")),
+		Color(232+15)<<shiftUl|flagUl|BoldFm)
+	testFunc(t, "NoUnderlineColor", NoUnderlineColor(UnderlineGray(15, "x")),
+		0)
+}
+
+func Test_ecma48(t *testing.T) {
+	testFunc(t, "ProportionalSpacing", ProportionalSpacing("x"),
+		ProportionalSpacingFm)
+	testFunc(t, "IdeogramUnderline", IdeogramUnderline("x"),
+		IdeogramUnderlineFm)
+	testFunc(t, "IdeogramDoubleUnderline", IdeogramDoubleUnderline("x"),
+		IdeogramDoubleUnderlineFm)
+	testFunc(t, "IdeogramOverline", IdeogramOverline("x"), IdeogramOverlineFm)
+	testFunc(t, "IdeogramDoubleOverline", IdeogramDoubleOverline("x"),
+		IdeogramDoubleOverlineFm)
+	testFunc(t, "IdeogramStress", IdeogramStress("x"), IdeogramStressFm)
+	testFunc(t, "Superscript", Superscript("x"), SuperscriptFm)
+	testFunc(t, "Subscript", Subscript(Superscript("x")), SubscriptFm)
+	testFunc(t, "Font", Font(7, "x"), Color(7)<<shiftFont)
+	testFunc(t, "NoProportionalSpacing",
+		NoProportionalSpacing(ProportionalSpacing("x")), 0)
+	testFunc(t, "NoIdeograms", NoIdeograms(IdeogramStress("x")), 0)
+	testFunc(t, "NoScript", NoScript(Subscript("x")), 0)
+	testFunc(t, "NoFont", NoFont(Font(7, "x")), 0)
+}
+
 func Test_Colorize(t *testing.T) {
 	testFunc(t, "Colorize", Colorize("x", RedFg|BoldFm), RedFg|BoldFm)
 	testFunc(t, "Complex Colorize",
@@ -397,6 +439,20 @@ func Test_Colorize(t *testing.T) {
 	)
 }
 
+func Test_removal(t *testing.T) {
+	var v = Colorize("x", BoldFm|ItalicFm|UnderlineFm|RapidBlinkFm|RedFg|
+		BlueBg)
+	testFunc(t, "NoBold", NoBold(v), v.Color()&^BoldFm)
+	testFunc(t, "NoItalic", NoItalic(v), v.Color()&^ItalicFm)
+	testFunc(t, "NoUnderline", NoUnderline(v), v.Color()&^UnderlineFm)
+	testFunc(t, "NoBlink", NoBlink(v), v.Color()&^RapidBlinkFm)
+	testFunc(t, "NoForeground", NoForeground(v), v.Color()&^RedFg)
+	testFunc(t, "NoBackground", NoBackground(v), v.Color()&^BlueBg)
+	testFunc(t, "Without", Without(v, BoldFm|BlackFg),
+		v.Color()&^(BoldFm|RedFg))
+	testFunc(t, "NoBold plain", NoBold("x"), 0)
+}
+
 func Test_bigGray(t *testing.T) {
 	testFunc(t, "Gray", Gray(115, "x"), Color(232+23)<<shiftFg|flagFg)
 	testFunc(t, "BgGray", BgGray(215, "x"), Color(232+23)<<shiftBg|flagBg)
@@ -406,7 +462,7 @@ func Test_Hyperlink(t *testing.T) {
 	assert.EqualValues(t,
 		Value{
 			value: "Example",
-			cc:    DefaultColorizer.cc,
+			cc:    Default().load().cc,
 			hyperlink: &hyperlink{
 				target: "http://example.com",
 				params: []HyperlinkParam{{
@@ -420,7 +476,7 @@ func Test_Hyperlink(t *testing.T) {
 	assert.EqualValues(t,
 		Value{
 			value: "Example",
-			cc:    DefaultColorizer.cc | colorConfig(RedFg),
+			cc:    Default().load().cc | colorConfig(RedFg),
 			hyperlink: &hyperlink{
 				target: "http://example.com",
 				params: []HyperlinkParam{{
@@ -451,3 +507,52 @@ func Test_HyperlinkParams(t *testing.T) {
 			Red("Example"), "http://example.com", HyperlinkID("10")),
 		))
 }
+
+func TestSetDefault(t *testing.T) {
+	var prev = Default()
+	defer SetDefault(prev)
+
+	var a = New(WithColors(false))
+	SetDefault(a)
+	assert.True(t, a == Default())
+	assert.Equal(t, "x", Red("x").String())
+	SetDefault(nil)
+	assert.NotNil(t, Default())
+	assert.False(t, a == Default())
+	// concurrent
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				SetDefault(New(WithColors(j%2 == 0)))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = Red("x").String()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDefaultColorizer(t *testing.T) {
+	var prev, prevVar = Default(), DefaultColorizer
+	defer func() { DefaultColorizer = prevVar; SetDefault(prev) }()
+
+	var a, b = New(WithColors(false)), New()
+	DefaultColorizer = a // deprecated assignment
+	assert.True(t, a == Default())
+	assert.Equal(t, "x", Red("x").String())
+	SetDefault(b) // the latest wins
+	assert.True(t, b == Default())
+	assert.Equal(t, "\033[31mx\033[0m", Red("x").String())
+	var c = New(WithHyperlinks(false))
+	DefaultColorizer = c
+	assert.True(t, c == Default())
+	DefaultColorizer = nil
+	assert.True(t, b == Default())
+}